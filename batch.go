@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// FontSpec is a font to convert with ConvertAll.
+type FontSpec struct {
+	// Path is the OTF/TTF file to convert.
+	Path string
+	// Size is the font size in pixels.
+	Size int
+	// Output is the BDF file to write.
+	Output string
+	// Options configure the converter.
+	Options []Option
+}
+
+// ConvertResult is the result of a conversion of ConvertAll.
+type ConvertResult struct {
+	Spec FontSpec
+	// Err is the error of the conversion, or nil when it succeeded.
+	Err error
+}
+
+// ConvertAll converts the fonts concurrently, with up to GOMAXPROCS
+// conversions at once. The results are in the same order as fonts. A failed
+// conversion sets Err of its result and doesn't abort the others. The
+// returned error is only for the cancellation of ctx: the fonts not started
+// by then have ctx.Err() as Err.
+func ConvertAll(ctx context.Context, fonts []FontSpec) ([]ConvertResult, error) {
+	return convertAll(ctx, fonts, runtime.GOMAXPROCS(0))
+}
+
+func convertAll(ctx context.Context, fonts []FontSpec, jobs int) ([]ConvertResult, error) {
+	results := make([]ConvertResult, len(fonts))
+	sem := make(chan struct{}, max(jobs, 1))
+	var wg sync.WaitGroup
+	for i, spec := range fonts {
+		results[i].Spec = spec
+		// select picks a free slot as likely as the cancellation.
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].Err = convertSpec(spec)
+		}()
+	}
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+func convertSpec(spec FontSpec) (err error) {
+	cvt, err := NewBDFConverter(spec.Path, spec.Size, spec.Options...)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(cvt, &err)
+	return cvt.Convert(spec.Output)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertAllPartialFailure(t *testing.T) {
+	font := writeGoRegular(t)
+	dir := t.TempDir()
+	only := WithRuneFilter(inRuneRanges([]runeRange{{'A', 'B'}}))
+	fonts := []FontSpec{
+		{Path: font, Size: 16, Output: filepath.Join(dir, "a.bdf"), Options: []Option{only}},
+		{Path: filepath.Join(dir, "missing.ttf"), Size: 16, Output: filepath.Join(dir, "b.bdf")},
+		{Path: font, Size: 12, Output: filepath.Join(dir, "no-such-dir", "c.bdf"), Options: []Option{only}},
+		{Path: font, Size: 12, Output: filepath.Join(dir, "d.bdf"), Options: []Option{only}},
+	}
+	results, err := ConvertAll(context.Background(), fonts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(fonts) {
+		t.Fatalf("%d results; want %d", len(results), len(fonts))
+	}
+	for i, want := range []bool{true, false, false, true} {
+		r := results[i]
+		if r.Spec.Output != fonts[i].Output {
+			t.Errorf("result %d is of %s; want %s in the order of the input", i, r.Spec.Output, fonts[i].Output)
+		}
+		if ok := r.Err == nil; ok != want {
+			t.Errorf("%s: got %v; want success %t", r.Spec.Output, r.Err, want)
+		}
+		if _, err := os.Stat(r.Spec.Output); (err == nil) != want {
+			t.Errorf("%s: written %t; want %t", r.Spec.Output, err == nil, want)
+		}
+	}
+	if !errors.Is(results[1].Err, fs.ErrNotExist) {
+		t.Errorf("%s: got %v; want fs.ErrNotExist", fonts[1].Path, results[1].Err)
+	}
+	if got := parseBDFFile(t, fonts[3].Output).Size[0]; got != 12 {
+		t.Errorf("%s: SIZE %d; want 12", fonts[3].Output, got)
+	}
+}
+
+func TestConvertAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fonts := []FontSpec{{Path: "a.ttf", Size: 16}, {Path: "b.ttf", Size: 16}}
+	results, err := ConvertAll(ctx, fonts)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v; want context.Canceled", err)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("%s: got %v; want context.Canceled", r.Spec.Path, r.Err)
+		}
+	}
+}
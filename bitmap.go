@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+)
+
+// DetectBitmapFont returns the native sizes in pixels of the embedded bitmaps
+// of the font, and whether the font has only bitmaps and no outlines, like
+// color emoji fonts with CBDT. It takes the raw tables, as sfnt.Font doesn't
+// expose them.
+func DetectBitmapFont(raw *sfnttab.Font) (nativeSizes []int, isBitmapOnly bool, err error) {
+	nativeSizes, err = raw.BitmapSizes()
+	if err != nil {
+		return nil, false, err
+	}
+	return nativeSizes, len(nativeSizes) > 0 && !raw.HasOutlines(), nil
+}
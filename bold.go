@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// WithBold makes the glyphs bold synthetically, by OR-ing each bitmap with
+// itself shifted by a pixel to the right. The advances are not changed, and
+// the weight of the XLFD is "Bold".
+func WithBold() Option {
+	return func(cvt *BDFConverter) {
+		cvt.bold = true
+	}
+}
+
+// WithStrokeWidth thickens the strokes of the glyphs by width pixels, by
+// dilating each bitmap with a disk of the radius width/2. The pixels expanded
+// out of the cell are lost, and the advances are not changed.
+func WithStrokeWidth(width float64) Option {
+	return func(cvt *BDFConverter) {
+		cvt.strokeWidth = width
+	}
+}
+
+// embolden ORs img with itself shifted by a pixel to the right. The pixels
+// shifted out of the cell are lost.
+func embolden(img *bitimg.Image) {
+	shifted := img.Clone()
+	shifted.Shift(1, 0)
+	// Or never fails here, as both images have the same size.
+	_ = img.Or(shifted)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+func TestEmbolden(t *testing.T) {
+	img := bitimg.New(image.Rect(0, 0, 10, 1))
+	for _, x := range []int{0, 4, 5, 9} {
+		img.Set(x, 0, bitimg.Bit(true))
+	}
+	embolden(img)
+	// The pixel shifted out of the cell is lost.
+	if got, want := img.String(), "1100111001\n"; got != want {
+		t.Errorf("embolden: %q; want %q", got, want)
+	}
+}
+
+func TestBold(t *testing.T) {
+	only := WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}}))
+	plain, bold := convertGoRegular(t, 16, only), convertGoRegular(t, 16, only, WithBold())
+	if len(bold.Glyphs) != len(plain.Glyphs) {
+		t.Fatalf("%d bold glyphs; want %d", len(bold.Glyphs), len(plain.Glyphs))
+	}
+	for i, p := range plain.Glyphs {
+		b := bold.Glyphs[i]
+		if b.DWidth != p.DWidth || b.BBX != p.BBX {
+			t.Errorf("%s: DWIDTH %v and BBX %v; want %v and %v unchanged", b.Name, b.DWidth, b.BBX, p.DWidth, p.BBX)
+			continue
+		}
+		digits := len(p.Bitmap[0])
+		mask := uint64(1)<<(digits*4) - 1<<(digits*4-p.BBX[0])
+		for y, row := range p.Bitmap {
+			v, err := strconv.ParseUint(row, 16, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf("%0*X", digits, (v|v>>1)&mask); b.Bitmap[y] != want {
+				t.Errorf("%s: row %d %s; want %s, %s OR-ed with itself shifted", b.Name, y, b.Bitmap[y], want, row)
+			}
+		}
+	}
+}
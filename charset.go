@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// WithCharset sets CHARSET_REGISTRY and CHARSET_ENCODING, the last two fields
+// of the XLFD, instead of "ISO10646" and "1". For the 8-bit charsets known to
+// golang.org/x/text/encoding/charmap, like "ISO8859-1" and "KOI8-R", only the
+// runes in the charset are converted and ENCODING is their code in it. For
+// the other charsets, it is warned that ENCODING stays in Unicode.
+func WithCharset(registry, encoding string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.charsetRegistry, cvt.charsetEncoding = registry, encoding
+		cm := lookupCharmap(registry + "-" + encoding)
+		if cm == nil {
+			slog.Warn("remapping ENCODING to the charset is not implemented, writing Unicode code points",
+				"charset", registry+"-"+encoding)
+			return
+		}
+		cvt.charmap = cm
+		WithRuneFilter(func(r rune) bool {
+			_, ok := cm.EncodeRune(r)
+			return ok
+		})(cvt)
+	}
+}
+
+// parseCharset parses a charset like "ISO8859-1" into CHARSET_REGISTRY and
+// CHARSET_ENCODING.
+func parseCharset(s string) (registry, encoding string, err error) {
+	i := strings.LastIndexByte(s, '-')
+	if i <= 0 || i == len(s)-1 {
+		return "", "", fmt.Errorf("invalid charset %q: must be REGISTRY-ENCODING, like ISO8859-1", s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// lookupCharmap returns the charmap of the charset, or nil. Names are
+// compared ignoring casing, spaces and hyphens, so "ISO8859-1" matches
+// "ISO 8859-1".
+func lookupCharmap(charset string) *charmap.Charmap {
+	key := looseCharsetName(charset)
+	for _, enc := range charmap.All {
+		cm, ok := enc.(*charmap.Charmap)
+		if ok && looseCharsetName(cm.String()) == key {
+			return cm
+		}
+	}
+	return nil
+}
+
+func looseCharsetName(s string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(s))
+}
+
+// encoding returns the value of ENCODING of the rune r: its code in the
+// charset of WithCharset, or r itself.
+func (cvt *BDFConverter) encoding(r rune) int {
+	if cvt.charmap != nil {
+		if b, ok := cvt.charmap.EncodeRune(r); ok {
+			return int(b)
+		}
+	}
+	return int(r)
+}
+
+// decodeEncoding returns the rune of the value of ENCODING, which encoding
+// returns.
+func (cvt *BDFConverter) decodeEncoding(encoding int) rune {
+	if cvt.charmap != nil && encoding >= 0 && encoding <= 0xff {
+		return cvt.charmap.DecodeByte(byte(encoding))
+	}
+	return rune(encoding)
+}
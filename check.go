@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+)
+
+// runCheck runs "check" subcommand, which validates BDF files.
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errors.New("an argument is required: the BDF file to check")
+	}
+	failed := false
+	for _, name := range fs.Args() {
+		ok, err := checkBDF(name)
+		if err != nil {
+			return err
+		}
+		failed = failed || !ok
+	}
+	if failed {
+		return errors.New("check failed")
+	}
+	return nil
+}
+
+// checkBDF validates a BDF file and prints the report.
+func checkBDF(name string) (bool, error) {
+	f, err := openBDF(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	font, err := bdf.Parse(f)
+	if err != nil {
+		fmt.Printf("%s: FAIL: %s\n", name, err)
+		return false, nil
+	}
+	problems := bdf.Validate(font)
+	for _, p := range problems {
+		fmt.Printf("%s: %s\n", name, p)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%s: FAIL: %d problems in %d glyphs\n", name, len(problems), len(font.Glyphs))
+		return false, nil
+	}
+	fmt.Printf("%s: PASS: %d glyphs\n", name, len(font.Glyphs))
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const checksumPrefix = "COMMENT SHA256 "
+
+// ErrChecksumMismatch is returned when the checksum recorded in a BDF doesn't
+// match its content.
+var ErrChecksumMismatch = errors.New("BDF checksum mismatch")
+
+// verifyChecksum verifies the "COMMENT SHA256 <hex>" line of a BDF, which
+// records the SHA-256 of all content preceding the line. The line must be
+// written as WithChecksum does: in lowercase hex, and followed only by
+// ENDFONT, which isn't covered by the checksum otherwise.
+func verifyChecksum(data []byte) error {
+	idx := bytes.LastIndex(data, []byte("\n"+checksumPrefix))
+	if idx < 0 {
+		return errors.New("BDF has no checksum")
+	}
+	content := data[:idx+1]
+	line := data[idx+1+len(checksumPrefix):]
+	if n := bytes.IndexByte(line, '\n'); n >= 0 {
+		line = line[:n]
+	}
+	if _, err := hex.DecodeString(string(bytes.TrimSpace(line))); err != nil {
+		return fmt.Errorf("invalid checksum: %w", err)
+	}
+	want := fmt.Sprintf("%s%x\nENDFONT\n", checksumPrefix, sha256.Sum256(content))
+	if string(data[idx+1:]) != want {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// runVerifyChecksum verifies the checksum of the BDF file and reports it.
+func runVerifyChecksum(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(data); err != nil {
+		return fmt.Errorf("%s: FAIL: %w", name, err)
+	}
+	fmt.Printf("%s: OK\n", name)
+	return nil
+}
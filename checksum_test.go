@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithChecksum(),
+		WithRuneFilter(inRuneRanges([]runeRange{{'A', 'A'}})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	data, err := cvt.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyChecksum(data); err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		modified := append([]byte(nil), data...)
+		modified[i] ^= 0x01
+		if err := verifyChecksum(modified); err == nil {
+			t.Errorf("modifying %q at %d: got no error", data[i], i)
+		}
+	}
+	if err := verifyChecksum(append(data, "COMMENT\n"...)); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("appending a line: got %v; want ErrChecksumMismatch", err)
+	}
+}
+
+func TestRunVerifyChecksum(t *testing.T) {
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	if err := Run(context.Background(), []string{"-quiet", "-checksum", "-range", "U+0041-U+0041", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(context.Background(), []string{"-verify-checksum", out}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 0x01
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(context.Background(), []string{"-verify-checksum", out}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("-verify-checksum of a modified file: got %v; want ErrChecksumMismatch", err)
+	}
+}
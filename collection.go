@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+// errNotCollection is returned for a font index other than 0 of a single font.
+func errNotCollection(index int) error {
+	return fmt.Errorf("font index %d out of range: the font is not a collection", index)
+}
+
+// parseRaw parses the table directory of the index-th font in b, which is a
+// single font or a TrueType Collection.
+func parseRaw(b []byte, index int) (*sfnttab.Font, error) {
+	if sfnttab.IsCollection(b) {
+		return sfnttab.ParseCollection(b, index)
+	}
+	if index != 0 {
+		return nil, errNotCollection(index)
+	}
+	return sfnttab.Parse(b)
+}
+
+// parseFont parses the index-th font in b, which is a single font or a
+// TrueType Collection.
+func parseFont(b []byte, index int) (*sfnt.Font, error) {
+	if !sfnttab.IsCollection(b) {
+		if index != 0 {
+			return nil, errNotCollection(index)
+		}
+		return opentype.Parse(b)
+	}
+	c, err := opentype.ParseCollection(b)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= c.NumFonts() {
+		return nil, fmt.Errorf("font index %d out of range: the collection has %d fonts", index, c.NumFonts())
+	}
+	return c.Font(index)
+}
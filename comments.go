@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum length of lines in BDF, excluding the line
+// terminator.
+const maxLineLength = 1023
+
+// maxCommentLength is the maximum length of the text of a COMMENT line.
+const maxCommentLength = maxLineLength - len("COMMENT ")
+
+// WithComments adds COMMENT lines right after STARTFONT. Texts with multiple
+// lines become multiple COMMENT lines. A line longer than maxCommentLength
+// bytes is split into several COMMENT lines at rune boundaries, rather than
+// truncated, so that no line exceeds the limit of maxLineLength bytes of BDF.
+func WithComments(texts ...string) Option {
+	return func(cvt *BDFConverter) {
+		for _, text := range texts {
+			cvt.comments = append(cvt.comments, commentLines(text)...)
+		}
+	}
+}
+
+// commentLines splits text into lines of COMMENT, with at most
+// maxCommentLength bytes each. Lines are split at rune boundaries.
+func commentLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		for len(line) > maxCommentLength {
+			n := maxCommentLength
+			for n > 0 && !utf8.RuneStart(line[n]) {
+				n--
+			}
+			lines = append(lines, line[:n])
+			line = line[n:]
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// licenseNames are the names of license files, which findLicenseFile looks
+// for next to fonts.
+var licenseNames = []string{"LICENSE", "LICENSE.txt", "OFL.txt"}
+
+// findLicenseFile returns the license file in the directory of the font file
+// fontName, or "" when there is none.
+func findLicenseFile(fontName string) string {
+	dir := filepath.Dir(fontName)
+	for _, name := range licenseNames {
+		p := filepath.Join(dir, name)
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+			return p
+		}
+	}
+	return ""
+}
+
+// readCommentFile reads the text of COMMENT lines from the file name.
+func readCommentFile(name string) (string, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCommentLines(t *testing.T) {
+	long := strings.Repeat("a", maxCommentLength-1) + "é" + strings.Repeat("b", 10)
+	for _, tc := range []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", []string{""}},
+		{"one line", "hello", []string{"hello"}},
+		{"trailing newline", "hello\n", []string{"hello"}},
+		{"CRLF", "a\r\nb\r\n", []string{"a", "b"}},
+		{"exact", strings.Repeat("x", maxCommentLength), []string{strings.Repeat("x", maxCommentLength)}},
+		{"split", strings.Repeat("x", maxCommentLength+1), []string{strings.Repeat("x", maxCommentLength), "x"}},
+		// The 2-byte rune which crosses the limit moves to the next line.
+		{"rune boundary", long, []string{strings.Repeat("a", maxCommentLength-1), "é" + strings.Repeat("b", 10)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := commentLines(tc.text); !slices.Equal(got, tc.want) {
+				t.Errorf("got %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunComments(t *testing.T) {
+	font := writeGoRegular(t)
+	dir := t.TempDir()
+	long := strings.Repeat("あ", 700) // 2100 bytes
+	commentFile := filepath.Join(dir, "comments.txt")
+	if err := os.WriteFile(commentFile, []byte("file line 1\nfile line 2\n"+long+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.bdf")
+	args := []string{"-quiet", "-range", "U+0041-U+0041", "-comment", "first", "-comment", "second", "-comment-file", commentFile, "-out", out, font}
+	if err := Run(context.Background(), args); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var comments []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if len(line) > maxLineLength {
+			t.Errorf("line of %d bytes exceeds %d: %.40s...", len(line), maxLineLength, line)
+		}
+		if text, ok := strings.CutPrefix(line, "COMMENT "); ok {
+			if !utf8.ValidString(text) {
+				t.Errorf("COMMENT splits a rune: %q", text)
+			}
+			comments = append(comments, text)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"first", "second", "file line 1", "file line 2"}
+	if len(comments) < len(want)+3 || !slices.Equal(comments[:len(want)], want) {
+		t.Fatalf("COMMENT lines %q; want %q, then the long line", comments, want)
+	}
+	// The long line is split into COMMENT lines, which make it up in order.
+	rest := comments[len(want):]
+	if got := strings.Join(rest[:3], ""); got != long {
+		t.Errorf("long line is split into %d, %d and %d bytes, which don't make it up", len(rest[0]), len(rest[1]), len(rest[2]))
+	}
+}
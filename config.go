@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/koron/otf2ccbdf/internal/toml"
+)
+
+// Keys of config files which are not flags.
+const (
+	configKeyInputs    = "inputs"
+	configKeyInput     = "input"
+	configKeyFallbacks = "fallbacks"
+)
+
+// loadConfig reads a TOML config file. Its keys are the names of the flags of
+// Run, and "input" and "fallbacks" for the arguments. Each table of the
+// [[inputs]] array is a conversion, which may override the keys.
+func loadConfig(name string) (toml.Table, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := toml.Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if v, ok := cfg[configKeyInputs]; ok {
+		if _, ok := v.([]toml.Table); !ok {
+			return nil, fmt.Errorf("%s: %q must be an array of tables", name, configKeyInputs)
+		}
+	}
+	return cfg, nil
+}
+
+// configInputs returns the tables of [[inputs]] in cfg.
+func configInputs(cfg toml.Table) []toml.Table {
+	inputs, _ := cfg[configKeyInputs].([]toml.Table)
+	return inputs
+}
+
+// runConfigInputs runs a conversion for each table of [[inputs]], by running
+// Run with args and -config-input.
+func runConfigInputs(ctx context.Context, args []string, n int) error {
+	for i := range n {
+		if err := Run(ctx, append([]string{"-config-input", strconv.Itoa(i)}, args...)); err != nil {
+			return fmt.Errorf("inputs[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// applyConfig sets the flags of fs from the tables, unless they are set on the
+// command line. The earlier tables take precedence. It returns the arguments
+// from "input" and "fallbacks".
+func applyConfig(fs *flag.FlagSet, tables ...toml.Table) ([]string, error) {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	var input string
+	var fallbacks []string
+	for _, t := range tables {
+		for k, v := range t {
+			if k == configKeyInputs || set[k] {
+				continue
+			}
+			set[k] = true
+			values, err := configValues(v)
+			if err != nil {
+				return nil, fmt.Errorf("config %q: %w", k, err)
+			}
+			switch k {
+			case configKeyInput:
+				if len(values) != 1 {
+					return nil, fmt.Errorf("config %q must be a string", k)
+				}
+				input = values[0]
+				continue
+			case configKeyFallbacks:
+				fallbacks = values
+				continue
+			}
+			if fs.Lookup(k) == nil {
+				return nil, fmt.Errorf("config %q: no such flag", k)
+			}
+			for _, s := range values {
+				if err := fs.Set(k, s); err != nil {
+					return nil, fmt.Errorf("config %q: %w", k, err)
+				}
+			}
+		}
+	}
+	if input == "" {
+		return nil, nil
+	}
+	return append([]string{input}, fallbacks...), nil
+}
+
+// configValues converts a value of the config to the values of a flag. Arrays
+// are for the repeatable flags.
+func configValues(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'g', -1, 64)}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case []any:
+		var values []string
+		for _, e := range v {
+			if _, ok := e.([]any); ok {
+				return nil, fmt.Errorf("nested arrays are not supported")
+			}
+			s, err := configValues(e)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s...)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("tables are not supported")
+	}
+}
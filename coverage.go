@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"unicode"
+)
+
+// readRequiredChars reads the characters in a UTF-8 text file, excluding
+// white spaces and control characters. The result is sorted and unique.
+func readRequiredChars(name string) ([]rune, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var runes []rune
+	for _, r := range string(b) {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			continue
+		}
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	return slices.Compact(runes), nil
+}
+
+// fontRunes returns the set of runes which the font has.
+func fontRunes(name string) (map[rune]bool, error) {
+	cvt, err := NewBDFConverter(name, 16)
+	if err != nil {
+		return nil, err
+	}
+	defer cvt.Close()
+	set := map[rune]bool{}
+	for r := range cvt.glyphs() {
+		set[r] = true
+	}
+	return set, nil
+}
+
+// runCoverage runs "coverage" subcommand, which compares the coverage of the
+// required characters by two fonts.
+func runCoverage(args []string) error {
+	var required string
+	fs := flag.NewFlagSet("coverage", flag.ExitOnError)
+	fs.StringVar(&required, "required", "", `text file of the required characters`)
+	fs.Parse(args)
+	if required == "" {
+		return errors.New("-required must be specified")
+	}
+	if fs.NArg() != 2 {
+		return errors.New("two arguments are required: the OTF/TTF files to compare")
+	}
+	nameA, nameB := fs.Arg(0), fs.Arg(1)
+
+	chars, err := readRequiredChars(required)
+	if err != nil {
+		return err
+	}
+	setA, err := fontRunes(nameA)
+	if err != nil {
+		return err
+	}
+	setB, err := fontRunes(nameB)
+	if err != nil {
+		return err
+	}
+
+	var coveredA, coveredB int
+	var onlyA, onlyB, neither []rune
+	for _, r := range chars {
+		a, b := setA[r], setB[r]
+		if a {
+			coveredA++
+		}
+		if b {
+			coveredB++
+		}
+		switch {
+		case a && !b:
+			onlyA = append(onlyA, r)
+		case !a && b:
+			onlyB = append(onlyB, r)
+		case !a && !b:
+			neither = append(neither, r)
+		}
+	}
+	percent := func(n int) float64 {
+		if len(chars) == 0 {
+			return 0
+		}
+		return float64(n) * 100 / float64(len(chars))
+	}
+	fmt.Printf("required: %d\n", len(chars))
+	fmt.Printf("%s: %d (%.1f%%)\n", nameA, coveredA, percent(coveredA))
+	fmt.Printf("%s: %d (%.1f%%)\n", nameB, coveredB, percent(coveredB))
+	fmt.Printf("only in %s: %d %s\n", nameA, len(onlyA), string(onlyA))
+	fmt.Printf("only in %s: %d %s\n", nameB, len(onlyB), string(onlyB))
+	fmt.Printf("in neither: %d %s\n", len(neither), string(neither))
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+)
+
+// dumpFontTables prints the tag, size and offset of each SFNT table in the
+// font file.
+func dumpFontTables(w io.Writer, name string) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	raw, err := sfnttab.Parse(b)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%-4s | %10s | %10s\n", "Tag", "Size", "Offset")
+	for _, t := range raw.Tables {
+		fmt.Fprintf(w, "%-4s | %10d | %10d\n", t.Tag, t.Length, t.Offset)
+	}
+	return nil
+}
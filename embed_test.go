@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// goRegularTTF is Go Regular, which is licensed under
+// testdata/Go-Regular.LICENSE.
+//
+//go:embed testdata/Go-Regular.ttf
+var goRegularTTF []byte
+
+func TestNewBDFConverterFromEmbeddedBytes(t *testing.T) {
+	if !bytes.Equal(goRegularTTF, goregular.TTF) {
+		t.Fatal("testdata/Go-Regular.ttf differs from goregular.TTF")
+	}
+	cvt, err := NewBDFConverterFromBytes(goRegularTTF, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	if got := cvt.FamilyName(); got != "Go" {
+		t.Errorf("FamilyName() = %q; want Go", got)
+	}
+	got, err := cvt.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromFile, err := NewBDFConverter(writeGoRegular(t), 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fromFile.Close()
+	want, err := fromFile.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("BDF converted from the embedded bytes differs from the one from the file")
+	}
+}
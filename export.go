@@ -0,0 +1,160 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/math/fixed"
+)
+
+// glyphRecord is a glyph collected during the conversion for exports.
+type glyphRecord struct {
+	r     rune
+	adv   fixed.Int26_6
+	width int
+	img   *bitimg.Image
+}
+
+// collect returns an observer which appends copies of the glyphs to recs.
+func collect(recs *[]glyphRecord) func(rune, fixed.Int26_6, int, *bitimg.Image) {
+	return func(r rune, adv fixed.Int26_6, width int, img *bitimg.Image) {
+		cp := bitimg.New(img.Bounds())
+		draw.Draw(cp, cp.Bounds(), img, img.Bounds().Min, draw.Src)
+		*recs = append(*recs, glyphRecord{r: r, adv: adv, width: width, img: cp})
+	}
+}
+
+// ExportBundle writes a zip archive which contains the BDF, the glyph atlas
+// PNG, the metrics CSV and the JSON report, generated in a single pass.
+func (cvt *BDFConverter) ExportBundle(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	var recs []glyphRecord
+	saved := cvt.observe
+	cvt.observe = collect(&recs)
+	defer func() { cvt.observe = saved }()
+
+	entries := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{fmt.Sprintf("%s-%d.bdf", cvt.name, cvt.size), cvt.write},
+		{"atlas.png", func(w io.Writer) error { return cvt.writeAtlasPNG(w, recs) }},
+		{"metrics.csv", func(w io.Writer) error { return writeMetricsCSV(w, recs) }},
+		{"report.json", func(w io.Writer) error { return cvt.writeReportJSON(w, recs) }},
+	}
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			return err
+		}
+		if err := e.write(w); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// atlasColumns is the number of glyph cells in a row of the atlas.
+const atlasColumns = 32
+
+// writeAtlasPNG writes all glyphs in a grid of full width cells as PNG.
+func (cvt *BDFConverter) writeAtlasPNG(w io.Writer, recs []glyphRecord) error {
+	rows := max((len(recs)+atlasColumns-1)/atlasColumns, 1)
+	atlas := image.NewGray(image.Rect(0, 0, atlasColumns*cvt.fullWidth, rows*cvt.height))
+	for i, rec := range recs {
+		pt := image.Pt(i%atlasColumns*cvt.fullWidth, i/atlasColumns*cvt.height)
+		b := rec.img.Bounds()
+		draw.Draw(atlas, b.Sub(b.Min).Add(pt), rec.img, b.Min, draw.Src)
+	}
+	return png.Encode(w, atlas)
+}
+
+// runeHex returns r as "U+XXXX", or "" for -1 of the unmapped glyphs.
+func runeHex(r rune) string {
+	if r < 0 {
+		return ""
+	}
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// writeMetricsCSV writes the metrics of the glyphs as CSV.
+func writeMetricsCSV(w io.Writer, recs []glyphRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"codepoint", "hex", "advance", "width"})
+	for _, rec := range recs {
+		cw.Write([]string{
+			strconv.Itoa(int(rec.r)),
+			runeHex(rec.r),
+			strconv.FormatFloat(float64(rec.adv)/64, 'f', -1, 64),
+			strconv.Itoa(rec.width),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type report struct {
+	Family          string        `json:"family"`
+	Size            int           `json:"size"`
+	Ascent          int           `json:"ascent"`
+	Descent         int           `json:"descent"`
+	Chars           int           `json:"chars"`
+	HalfWidthGlyphs int           `json:"halfWidthGlyphs"`
+	FullWidthGlyphs int           `json:"fullWidthGlyphs"`
+	Glyphs          []reportGlyph `json:"glyphs"`
+}
+
+type reportGlyph struct {
+	Codepoint int           `json:"codepoint"`
+	Hex       string        `json:"hex"`
+	Advance   float64       `json:"advance"`
+	Width     int           `json:"width"`
+	Bitmap    *bitimg.Image `json:"bitmap,omitempty"`
+}
+
+// writeReportJSON writes a report of the font and the glyphs as JSON.
+func (cvt *BDFConverter) writeReportJSON(w io.Writer, recs []glyphRecord) error {
+	rep := report{
+		Family:  cvt.name,
+		Size:    cvt.size,
+		Ascent:  cvt.ascent,
+		Descent: cvt.descent,
+		Chars:   len(recs),
+		Glyphs:  make([]reportGlyph, 0, len(recs)),
+	}
+	for _, rec := range recs {
+		if rec.width == cvt.fullWidth {
+			rep.FullWidthGlyphs++
+		} else {
+			rep.HalfWidthGlyphs++
+		}
+		rep.Glyphs = append(rep.Glyphs, reportGlyph{
+			Codepoint: int(rec.r),
+			Hex:       runeHex(rec.r),
+			Advance:   float64(rec.adv) / 64,
+			Width:     rec.width,
+			Bitmap:    rec.img,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// WithFallbackFonts adds OTF/TTF fonts, which provide the glyphs missing in
+// the font to convert. For each rune, the first font which has it, in the
+// order of the font to convert and of data, is used. The glyphs of the
+// fallback fonts are placed with the metrics of the font to convert.
+func WithFallbackFonts(data ...[]byte) Option {
+	return func(cvt *BDFConverter) {
+		cvt.fallbackData = append(cvt.fallbackData, data...)
+	}
+}
+
+// mergedFace is a font.Face which draws each rune with the first face which
+// has it. The metrics are those of the first face.
+type mergedFace []font.Face
+
+// faceOf returns the face which has the rune r, or the first face when none
+// has it.
+func (mf mergedFace) faceOf(r rune) font.Face {
+	for _, f := range mf {
+		if _, ok := f.GlyphAdvance(r); ok {
+			return f
+		}
+	}
+	return mf[0]
+}
+
+func (mf mergedFace) Close() error {
+	var errs []error
+	for _, f := range mf {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (mf mergedFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return mf.faceOf(r).Glyph(dot, r)
+}
+
+func (mf mergedFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return mf.faceOf(r).GlyphBounds(r)
+}
+
+func (mf mergedFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return mf.faceOf(r).GlyphAdvance(r)
+}
+
+// Kern returns the kerning of the pair only when both runes are in the same
+// face.
+func (mf mergedFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	f := mf.faceOf(r0)
+	if f != mf.faceOf(r1) {
+		return 0
+	}
+	return f.Kern(r0, r1)
+}
+
+func (mf mergedFace) Metrics() font.Metrics {
+	return mf[0].Metrics()
+}
+
+// openFace creates the font face of fnt, merged with the fallback fonts if
+// any.
+func (cvt *BDFConverter) openFace(fnt *sfnt.Font) (font.Face, error) {
+	face, err := opentype.NewFace(fnt, cvt.faceOpts)
+	if err != nil || len(cvt.fallbacks) == 0 {
+		return face, err
+	}
+	mf := mergedFace{face}
+	for _, fb := range cvt.fallbacks {
+		f, err := opentype.NewFace(fb, cvt.faceOpts)
+		if err != nil {
+			mf.Close()
+			return nil, err
+		}
+		mf = append(mf, f)
+	}
+	return mf, nil
+}
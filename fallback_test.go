@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestFallbackFonts(t *testing.T) {
+	// A subset of Go Regular which lacks N-Z, which Go Bold provides.
+	raw, err := sfnttab.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var runes []rune
+	for r := 'A'; r <= 'M'; r++ {
+		runes = append(runes, r)
+	}
+	subset, err := raw.Subset(runes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	only := WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}}))
+	got := convertFont(t, subset, 16, only, WithFallbackFonts(gobold.TTF))
+	if got.Chars != 26 {
+		t.Errorf("CHARS %d; want 26 of A-Z", got.Chars)
+	}
+	regular := convertFont(t, goregular.TTF, 16, only)
+	bold := convertFont(t, gobold.TTF, 16, only)
+	for _, tc := range []struct {
+		r    rune
+		want *bdf.Font
+	}{
+		{'A', regular},
+		{'M', regular},
+		{'N', bold},
+		{'Z', bold},
+	} {
+		g, want := findGlyph(t, got, int(tc.r)), findGlyph(t, tc.want, int(tc.r))
+		if g.DWidth != want.DWidth || !slices.Equal(g.Bitmap, want.Bitmap) {
+			t.Errorf("glyph of %c: DWIDTH %v, BITMAP %v; want %v, %v of %s", tc.r, g.DWidth, g.Bitmap, want.DWidth, want.Bitmap, tc.want.Name)
+		}
+	}
+}
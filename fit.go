@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"log/slog"
+	"strings"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// validateGlyphFit checks whether img has set pixels outside the bounding box
+// (0, 0)-(width, height). img is a canvas which may extend beyond the box on
+// each side.
+func validateGlyphFit(img *bitimg.Image, width, height int) (overflow bool, details string) {
+	b := img.Bounds()
+	var left, right, top, bottom int
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !img.BitAt(x, y) {
+				continue
+			}
+			left = max(left, -x)
+			right = max(right, x-width+1)
+			top = max(top, -y)
+			bottom = max(bottom, y-height+1)
+		}
+	}
+	var parts []string
+	for _, e := range []struct {
+		name string
+		n    int
+	}{{"left", left}, {"right", right}, {"top", top}, {"bottom", bottom}} {
+		if e.n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %dpx", e.name, e.n))
+		}
+	}
+	return len(parts) > 0, strings.Join(parts, ", ")
+}
+
+// glyphIssues counts the glyphs with issues found while writing the body.
+// Each glyph is logged at the debug level, and warn summarizes the counts, as
+// many glyphs of a font may have the same issue.
+type glyphIssues struct {
+	overflow    int
+	zeroAdvance int
+	blank       int
+}
+
+// warn logs the counts of the issues of the glyphs of the font.
+func (gi *glyphIssues) warn(font string) {
+	if gi.overflow > 0 {
+		slog.Warn("glyphs overflow the bounding box", "font", font, "glyphs", gi.overflow)
+	}
+	if gi.zeroAdvance > 0 {
+		slog.Warn("glyphs have zero advance", "font", font, "glyphs", gi.zeroAdvance)
+	}
+	if gi.blank > 0 {
+		slog.Warn("glyphs of non-space runes are blank", "font", font, "glyphs", gi.blank)
+	}
+}
+
+// checkGlyphFit checks whether the glyph of r fits in the cell of width, and
+// counts it in issues when it doesn't.
+func (cvt *BDFConverter) checkGlyphFit(r rune, width int, issues *glyphIssues) error {
+	bounds, _, ok := cvt.face.GlyphBounds(r)
+	if !ok {
+		return nil
+	}
+	return cvt.checkFit(slog.String("rune", fmt.Sprintf("U+%04X", r)), bounds, width, issues, func(canvas *bitimg.Image) error {
+		cvt.renderGlyph(canvas, r)
+		return nil
+	})
+}
+
+// checkGlyphIndexFit is checkGlyphFit for the unmapped glyph gid.
+func (cvt *BDFConverter) checkGlyphIndexFit(gid sfnt.GlyphIndex, width int, issues *glyphIssues) error {
+	bounds, err := cvt.glyphIndexBounds(gid)
+	if err != nil {
+		return nil
+	}
+	return cvt.checkFit(slog.Any("glyph", gid), bounds, width, issues, func(canvas *bitimg.Image) error {
+		return cvt.renderGlyphIndex(canvas, gid)
+	})
+}
+
+// checkFit checks whether the glyph of the outline bounds fits in the cell of
+// width. It calls render to draw the glyph to a larger canvas only when the
+// bounds exceed the cell. glyph identifies the glyph in the log and the error.
+func (cvt *BDFConverter) checkFit(glyph slog.Attr, bounds fixed.Rectangle26_6, width int, issues *glyphIssues, render func(*bitimg.Image) error) error {
+	top := -cvt.ascent
+	in := image.Rect(0, top, width, top+cvt.height)
+	minX, minY := bounds.Min.X.Floor(), bounds.Min.Y.Floor()
+	maxX, maxY := bounds.Max.X.Ceil(), bounds.Max.Y.Ceil()
+	if minX >= in.Min.X && minY >= in.Min.Y && maxX <= in.Max.X && maxY <= in.Max.Y {
+		return nil
+	}
+	margin := max(in.Min.X-minX, in.Min.Y-minY, maxX-in.Max.X, maxY-in.Max.Y, 0)
+	canvas := bitimg.NewWithThreshold(image.Rect(-margin, -margin, width+margin, cvt.height+margin), cvt.threshold)
+	if err := render(canvas); err != nil {
+		return err
+	}
+	overflow, details := validateGlyphFit(canvas, width, cvt.height)
+	if !overflow {
+		return nil
+	}
+	if cvt.strict {
+		return fmt.Errorf("glyph %s overflows the bounding box: %s", glyph.Value, details)
+	}
+	slog.Debug("glyph overflows the bounding box", glyph, "overflow", details)
+	issues.overflow++
+	return nil
+}
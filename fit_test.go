@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+// logRecorder is a slog.Handler which records the messages and the levels.
+type logRecorder struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *logRecorder) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *logRecorder) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *logRecorder) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *logRecorder) WithGroup(string) slog.Handler      { return h }
+
+// count returns the number of the records of msg at level.
+func (h *logRecorder) count(level slog.Level, msg string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, r := range h.records {
+		if r.Level == level && r.Message == msg {
+			n++
+		}
+	}
+	return n
+}
+
+// attr returns the value of the attribute key of the last record of msg.
+func (h *logRecorder) attr(msg, key string) slog.Value {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var v slog.Value
+	for _, r := range h.records {
+		if r.Message != msg {
+			continue
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == key {
+				v = a.Value
+			}
+			return true
+		})
+	}
+	return v
+}
+
+// recordLogs makes the default logger record into the returned handler until
+// the end of the test.
+func recordLogs(t testing.TB) *logRecorder {
+	h := &logRecorder{}
+	orig := slog.Default()
+	slog.SetDefault(slog.New(h))
+	t.Cleanup(func() { slog.SetDefault(orig) })
+	return h
+}
+
+func TestGlyphIssuesSummary(t *testing.T) {
+	logs := recordLogs(t)
+	convertGoRegular(t, 16)
+	for _, tc := range []struct {
+		glyph, summary string
+	}{
+		{"glyph overflows the bounding box", "glyphs overflow the bounding box"},
+		{"glyph has zero advance", "glyphs have zero advance"},
+		{"glyph of non-space rune is blank", "glyphs of non-space runes are blank"},
+	} {
+		n := logs.count(slog.LevelDebug, tc.glyph)
+		if n == 0 {
+			t.Errorf("no glyph of Go Regular is logged as %q at size 16", tc.glyph)
+			continue
+		}
+		if got := logs.count(slog.LevelWarn, tc.glyph); got != 0 {
+			t.Errorf("%d glyphs are warned as %q; want each at the debug level", got, tc.glyph)
+		}
+		if got := logs.count(slog.LevelWarn, tc.summary); got != 1 {
+			t.Errorf("%q is warned %d times; want once", tc.summary, got)
+		}
+		if got := logs.attr(tc.summary, "glyphs").Int64(); got != int64(n) {
+			t.Errorf("%q counts %d glyphs; want %d", tc.summary, got, n)
+		}
+	}
+}
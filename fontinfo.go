@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// nameIDLabels are the labels of the name IDs which "font-info" subcommand
+// prints. 15 is reserved.
+var nameIDLabels = map[sfnt.NameID]string{
+	sfnt.NameIDCopyright:            "Copyright",
+	sfnt.NameIDFamily:               "Family",
+	sfnt.NameIDSubfamily:            "Subfamily",
+	sfnt.NameIDUniqueIdentifier:     "Unique identifier",
+	sfnt.NameIDFull:                 "Full name",
+	sfnt.NameIDVersion:              "Version",
+	sfnt.NameIDPostScript:           "PostScript name",
+	sfnt.NameIDTrademark:            "Trademark",
+	sfnt.NameIDManufacturer:         "Manufacturer",
+	sfnt.NameIDDesigner:             "Designer",
+	sfnt.NameIDDescription:          "Description",
+	sfnt.NameIDVendorURL:            "Vendor URL",
+	sfnt.NameIDDesignerURL:          "Designer URL",
+	sfnt.NameIDLicense:              "License",
+	sfnt.NameIDLicenseURL:           "License URL",
+	15:                              "Reserved",
+	sfnt.NameIDTypographicFamily:    "Typographic family",
+	sfnt.NameIDTypographicSubfamily: "Typographic subfamily",
+	sfnt.NameIDCompatibleFull:       "Compatible full name",
+	sfnt.NameIDSampleText:           "Sample text",
+	sfnt.NameIDPostScriptCID:        "PostScript CID name",
+	sfnt.NameIDWWSFamily:            "WWS family",
+	sfnt.NameIDWWSSubfamily:         "WWS subfamily",
+}
+
+// nameRecord is a name of the font in the output of "font-info" subcommand.
+type nameRecord struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// fontNames returns the names of the index-th font in the file for the name
+// IDs 0 to 22, which are found.
+func fontNames(name string, index int) ([]nameRecord, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	fnt, err := parseFont(b, index)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := parseRaw(b, index)
+	if err != nil {
+		return nil, err
+	}
+	var recs []nameRecord
+	for id := sfnt.NameIDCopyright; id <= sfnt.NameIDWWSSubfamily; id++ {
+		v, err := preferredName(raw, fnt, id)
+		if errors.Is(err, sfnt.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("name ID %d: %w", id, err)
+		}
+		recs = append(recs, nameRecord{ID: int(id), Label: nameIDLabels[id], Value: v})
+	}
+	return recs, nil
+}
+
+// runFontInfo runs "font-info" subcommand, which prints the name records of a
+// font, to choose -name-id or the license to embed before converting it.
+func runFontInfo(args []string) error {
+	var (
+		index   int
+		useJSON bool
+	)
+	fs := flag.NewFlagSet("font-info", flag.ExitOnError)
+	fs.IntVar(&index, "font-index", 0, `index of the font in a TrueType Collection (.ttc)`)
+	fs.BoolVar(&useJSON, "json", false, `print the names as JSON`)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("an argument is required: the OTF/TTF file to inspect")
+	}
+	recs, err := fontNames(fs.Arg(0), index)
+	if err != nil {
+		return err
+	}
+	if useJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(recs)
+	}
+	for _, rec := range recs {
+		// Long values like the license text span lines, so they are joined
+		// to keep a record in a line.
+		fmt.Printf("%2d  %-22s  %s\n", rec.ID, rec.Label, strings.Join(strings.Fields(rec.Value), " "))
+	}
+	return nil
+}
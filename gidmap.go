@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+)
+
+// ExportGIDMap writes a TSV file, which maps glyph indices to code points,
+// sorted by glyph index.
+func (cvt *BDFConverter) ExportGIDMap(name string) error {
+	mappings, err := cvt.raw.CMap()
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(mappings, func(a, b sfnttab.Mapping) int {
+		return cmp.Compare(a.Glyph, b.Glyph)
+	})
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	fmt.Fprintln(w, "GID\tCodepoint\tHex\tWidth\tClassification")
+	for _, m := range mappings {
+		width, class := 0, "missing"
+		if adv, ok := cvt.face.GlyphAdvance(m.Rune); ok {
+			width = roundAdv(adv, cvt.rounding)
+			class = "half"
+			if cvt.isFullWidth(adv) {
+				class = "full"
+			}
+		}
+		fmt.Fprintf(w, "%d\t%d\tU+%04X\t%d\t%s\n", m.Glyph, m.Rune, m.Rune, width, class)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
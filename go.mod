@@ -2,6 +2,7 @@ module github.com/koron/otf2ccbdf
 
 go 1.24.6
 
-require golang.org/x/image v0.30.0
-
-require golang.org/x/text v0.28.0 // indirect
+require (
+	golang.org/x/image v0.30.0
+	golang.org/x/text v0.28.0
+)
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// renderGrayscale renders the rune r to a grayscale image, saves it as
+// "U+XXXX-gray.png" in gr.grayDir, and then thresholds it into img.
+func (gr *glyphRenderer) renderGrayscale(img *bitimg.Image, r rune) error {
+	gray := image.NewGray(img.Bounds())
+	drawer := &font.Drawer{
+		Dst:  gray,
+		Src:  image.NewUniform(color.White),
+		Face: gr.face,
+		Dot:  fixed.Point26_6{X: 0, Y: fixed.I(gr.ascent)},
+	}
+	drawer.DrawString(string(r))
+	if err := writeGrayPNG(filepath.Join(gr.grayDir, fmt.Sprintf("U+%04X-gray.png", r)), gray); err != nil {
+		return err
+	}
+	if err := img.Threshold(gray, gr.threshold); err != nil {
+		return err
+	}
+	gr.substituteBlank(img, r)
+	return nil
+}
+
+func writeGrayPNG(name string, gray *image.Gray) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := png.Encode(f, gray); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeDebugPNG writes the bitmap img of a glyph to the file name as PNG.
+func writeDebugPNG(name string, img *bitimg.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := img.EncodePNG(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
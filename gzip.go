@@ -0,0 +1,64 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// WithGzip compresses the BDF written by Convert with gzip. Convert also
+// compresses it when the name of the file ends with ".gz".
+func WithGzip() Option {
+	return func(cvt *BDFConverter) {
+		cvt.gzip = true
+	}
+}
+
+// isGzipName reports whether the file name has the extension of gzip.
+func isGzipName(name string) bool {
+	return strings.HasSuffix(name, ".gz")
+}
+
+// convertGzip converts the font to BDF, and writes it compressed to w. The
+// gzip stream is closed before returning, but w is not.
+func (cvt *BDFConverter) convertGzip(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if err := cvt.ConvertWriter(zw); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// gzipFile is a file read through a gzip reader. Close closes both.
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g *gzipFile) Close() error {
+	err := g.Reader.Close()
+	if err2 := g.f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// openBDF opens a BDF file to read, which is decompressed when its name ends
+// with ".gz".
+func openBDF(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if !isGzipName(name) {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipFile{Reader: zr, f: f}, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestConvertGzip(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	want, err := cvt.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := cvt.convertGzip(&buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zr.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("decompressed BDF differs from ConvertToBytes:\n%s", got)
+	}
+}
+
+func TestSuffixedOutName(t *testing.T) {
+	for _, tc := range []struct {
+		outName, want string
+	}{
+		{"z.bdf", "z-kern.bdf"},
+		{"z.bdf.gz", "z-kern.bdf.gz"},
+		{"z.gz", "z-kern.bdf.gz"},
+		{"z", "z-kern.bdf"},
+		{"dir/z.bdf", "dir/z-kern.bdf"},
+	} {
+		if got := suffixedOutName(tc.outName, "-kern"); got != tc.want {
+			t.Errorf("suffixedOutName(%q) = %q; want %q", tc.outName, got, tc.want)
+		}
+	}
+}
+
+func TestRunKernGzip(t *testing.T) {
+	font := writeGoRegular(t)
+	dir := t.TempDir()
+	kernMap := filepath.Join(dir, "kern.txt")
+	if err := os.WriteFile(kernMap, []byte("00C1 0041 00B4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "z.bdf.gz")
+	if err := Run(context.Background(), []string{"-quiet", "-range", "U+0041-U+0041", "-kern-bdf", kernMap, "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(dir, "z-kern.bdf.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := bdf.Parse(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Glyphs) != 1 || b.Glyphs[0].Encoding != 0xC1 {
+		t.Errorf("kern BDF has glyphs %+v; want U+00C1 only", b.Glyphs)
+	}
+}
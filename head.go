@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+)
+
+// sfntEpoch is the origin of LONGDATETIME in SFNT tables.
+var sfntEpoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// headTableModified returns the modification time of the font, recorded in
+// the "modified" field of the head table.
+func headTableModified(raw *sfnttab.Font) (time.Time, error) {
+	head := raw.Table("head")
+	if len(head) < 36 {
+		return time.Time{}, errors.New("head table is missing or too short")
+	}
+	secs := int64(binary.BigEndian.Uint64(head[28:]))
+	return time.Unix(sfntEpoch.Unix()+secs, 0).UTC(), nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"image"
+	"image/png"
+	"os"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/math/fixed"
+)
+
+// densityMap accumulates how often each pixel of a cell is set across glyphs.
+type densityMap struct {
+	w, h   int
+	counts []float64
+	glyphs int
+}
+
+func newDensityMap(w, h int) *densityMap {
+	return &densityMap{w: w, h: h, counts: make([]float64, w*h)}
+}
+
+// add is an observer of BDFConverter, which accumulates the glyph img.
+// Glyphs narrower than the cell are aligned to the left.
+func (dm *densityMap) add(_ rune, _ fixed.Int26_6, _ int, img *bitimg.Image) {
+	b := img.Bounds()
+	for y := 0; y < min(b.Dy(), dm.h); y++ {
+		for x := 0; x < min(b.Dx(), dm.w); x++ {
+			if img.BitAt(b.Min.X+x, b.Min.Y+y) {
+				dm.counts[y*dm.w+x]++
+			}
+		}
+	}
+	dm.glyphs++
+}
+
+// image returns the heatmap: the level of each pixel is the fraction of the
+// glyphs which have the pixel set.
+func (dm *densityMap) image() *image.Gray {
+	gray := image.NewGray(image.Rect(0, 0, dm.w, dm.h))
+	if dm.glyphs == 0 {
+		return gray
+	}
+	for i, c := range dm.counts {
+		gray.Pix[i] = uint8(c/float64(dm.glyphs)*255 + 0.5)
+	}
+	return gray
+}
+
+// writePNG writes the heatmap to the file name as PNG.
+func (dm *densityMap) writePNG(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := png.Encode(f, dm.image()); err != nil {
+		return err
+	}
+	return f.Close()
+}
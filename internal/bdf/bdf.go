@@ -0,0 +1,201 @@
+// Package bdf provides a parser and a validator of BDF (Glyph Bitmap
+// Distribution Format) files.
+package bdf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Font is a parsed BDF font.
+type Font struct {
+	Version     string
+	Name        string
+	Size        [3]int // point size, x and y resolutions
+	BoundingBox [4]int // width, height, x-offset and y-offset
+	Comments    []string
+	Properties  map[string]string
+	// Chars is the number of glyphs declared by CHARS.
+	Chars  int
+	Glyphs []*Glyph
+	// HasEndFont reports whether the font was terminated by ENDFONT.
+	HasEndFont bool
+}
+
+// Glyph is a glyph of a BDF font.
+type Glyph struct {
+	Name     string
+	Encoding int
+	SWidth   [2]int
+	DWidth   [2]int
+	BBX      [4]int // width, height, x-offset and y-offset
+	// Bitmap has hex-encoded rows of the bitmap, as written in BDF.
+	Bitmap []string
+	// Line is the line number of STARTCHAR.
+	Line int
+}
+
+// ParseError is an error of syntax in a BDF.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("bdf: line %d: %s", err.Line, err.Msg)
+}
+
+// Parse parses a BDF font. A file which ends without ENDFONT is truncated or
+// corrupted: Parse returns an error for it, with the font parsed so far.
+func Parse(r io.Reader) (*Font, error) {
+	p := &parser{sc: bufio.NewScanner(r)}
+	return p.parse()
+}
+
+type parser struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+func (p *parser) next() (key string, args []string, ok bool) {
+	for p.sc.Scan() {
+		p.line++
+		text := strings.TrimRight(p.sc.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		key, rest, _ := strings.Cut(text, " ")
+		if key == "COMMENT" {
+			return key, []string{rest}, true
+		}
+		return key, strings.Fields(rest), true
+	}
+	return "", nil, false
+}
+
+func (p *parser) errorf(format string, a ...any) error {
+	return &ParseError{Line: p.line, Msg: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) ints(key string, args []string, dst []int) error {
+	if len(args) < len(dst) {
+		return p.errorf("%s needs %d values", key, len(dst))
+	}
+	for i := range dst {
+		v, err := strconv.Atoi(args[i])
+		if err != nil {
+			return p.errorf("%s: %s", key, err)
+		}
+		dst[i] = v
+	}
+	return nil
+}
+
+func (p *parser) parse() (*Font, error) {
+	key, args, ok := p.next()
+	if !ok || key != "STARTFONT" || len(args) < 1 {
+		return nil, p.errorf("STARTFONT expected")
+	}
+	f := &Font{Version: args[0], Properties: map[string]string{}}
+	for {
+		key, args, ok := p.next()
+		if !ok {
+			if err := p.sc.Err(); err != nil {
+				return f, err
+			}
+			return f, p.errorf("ENDFONT expected")
+		}
+		switch key {
+		case "COMMENT":
+			f.Comments = append(f.Comments, args[0])
+		case "FONT":
+			f.Name = strings.Join(args, " ")
+		case "SIZE":
+			if err := p.ints(key, args, f.Size[:]); err != nil {
+				return nil, err
+			}
+		case "FONTBOUNDINGBOX":
+			if err := p.ints(key, args, f.BoundingBox[:]); err != nil {
+				return nil, err
+			}
+		case "STARTPROPERTIES":
+			if err := p.parseProperties(f); err != nil {
+				return nil, err
+			}
+		case "CHARS":
+			var n [1]int
+			if err := p.ints(key, args, n[:]); err != nil {
+				return nil, err
+			}
+			f.Chars = n[0]
+		case "STARTCHAR":
+			g, err := p.parseGlyph(strings.Join(args, " "))
+			if err != nil {
+				return nil, err
+			}
+			f.Glyphs = append(f.Glyphs, g)
+		case "ENDFONT":
+			f.HasEndFont = true
+			return f, nil
+		}
+	}
+}
+
+func (p *parser) parseProperties(f *Font) error {
+	for {
+		key, args, ok := p.next()
+		if !ok {
+			return p.errorf("ENDPROPERTIES expected")
+		}
+		if key == "ENDPROPERTIES" {
+			return nil
+		}
+		f.Properties[key] = strings.Trim(strings.Join(args, " "), `"`)
+	}
+}
+
+func (p *parser) parseGlyph(name string) (*Glyph, error) {
+	g := &Glyph{Name: name, Encoding: -1, Line: p.line}
+	for {
+		key, args, ok := p.next()
+		if !ok {
+			return nil, p.errorf("ENDCHAR expected")
+		}
+		switch key {
+		case "ENCODING":
+			var n [1]int
+			if err := p.ints(key, args, n[:]); err != nil {
+				return nil, err
+			}
+			g.Encoding = n[0]
+		case "SWIDTH":
+			if err := p.ints(key, args, g.SWidth[:]); err != nil {
+				return nil, err
+			}
+		case "DWIDTH":
+			if err := p.ints(key, args, g.DWidth[:]); err != nil {
+				return nil, err
+			}
+		case "BBX":
+			if err := p.ints(key, args, g.BBX[:]); err != nil {
+				return nil, err
+			}
+		case "BITMAP":
+			for {
+				key, args, ok := p.next()
+				if !ok {
+					return nil, p.errorf("ENDCHAR expected")
+				}
+				if key == "ENDCHAR" {
+					return g, nil
+				}
+				g.Bitmap = append(g.Bitmap, strings.Join(append([]string{key}, args...), ""))
+			}
+		case "ENDCHAR":
+			return g, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package bdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testBDF = `STARTFONT 2.1
+FONT -Test-Font-Medium-R-Normal--2-20-72-72-C-20-ISO10646-1
+SIZE 2 72 72
+FONTBOUNDINGBOX 2 2 0 0
+STARTPROPERTIES 1
+FONT_ASCENT 2
+ENDPROPERTIES
+CHARS 1
+STARTCHAR U+0041
+ENCODING 65
+SWIDTH 1000 0
+DWIDTH 2 0
+BBX 2 2 0 0
+BITMAP
+80
+40
+ENDCHAR
+ENDFONT
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(testBDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.HasEndFont || f.Chars != 1 || len(f.Glyphs) != 1 {
+		t.Fatalf("HasEndFont %t, CHARS %d, %d glyphs; want true, 1, 1", f.HasEndFont, f.Chars, len(f.Glyphs))
+	}
+	g := f.Glyphs[0]
+	if g.Name != "U+0041" || g.Encoding != 65 || g.DWidth != [2]int{2, 0} || strings.Join(g.Bitmap, " ") != "80 40" || g.Line != 9 {
+		t.Errorf("glyph %+v", g)
+	}
+	if got := f.Properties["FONT_ASCENT"]; got != "2" {
+		t.Errorf("FONT_ASCENT %q; want 2", got)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	for _, tc := range []struct {
+		name, src, msg string
+		line           int
+	}{
+		{"empty", "", "STARTFONT expected", 0},
+		{"no ENDFONT", strings.TrimSuffix(testBDF, "ENDFONT\n"), "ENDFONT expected", 17},
+		{"in a glyph", testBDF[:strings.Index(testBDF, "40\n")], "ENDCHAR expected", 15},
+		{"in the properties", testBDF[:strings.Index(testBDF, "ENDPROPERTIES")], "ENDPROPERTIES expected", 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.src))
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("got %v; want a ParseError", err)
+			}
+			if pe.Msg != tc.msg || pe.Line != tc.line {
+				t.Errorf("got %q at line %d; want %q at line %d", pe.Msg, pe.Line, tc.msg, tc.line)
+			}
+		})
+	}
+	// The font parsed so far is returned without ENDFONT.
+	f, err := Parse(strings.NewReader(strings.TrimSuffix(testBDF, "ENDFONT\n")))
+	if err == nil || f == nil || f.HasEndFont || len(f.Glyphs) != 1 {
+		t.Errorf("got %+v, %v; want the glyph parsed so far and an error", f, err)
+	}
+}
@@ -0,0 +1,77 @@
+package bdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problem is a problem found by Validate.
+type Problem struct {
+	// Glyph is the name of the glyph which has the problem, or empty for the
+	// problems of the whole font.
+	Glyph string
+	Msg   string
+}
+
+func (p Problem) String() string {
+	if p.Glyph == "" {
+		return p.Msg
+	}
+	return p.Glyph + ": " + p.Msg
+}
+
+// Validate checks the consistency of the font and returns found problems.
+func Validate(f *Font) []Problem {
+	var problems []Problem
+	add := func(glyph, format string, a ...any) {
+		problems = append(problems, Problem{Glyph: glyph, Msg: fmt.Sprintf(format, a...)})
+	}
+
+	if !f.HasEndFont {
+		add("", "ENDFONT is missing")
+	}
+	if f.Chars != len(f.Glyphs) {
+		add("", "CHARS is %d but %d glyphs found", f.Chars, len(f.Glyphs))
+	}
+
+	fbb := f.BoundingBox
+	encodings := map[int]string{}
+	for _, g := range f.Glyphs {
+		if g.Encoding >= 0 {
+			if prev, ok := encodings[g.Encoding]; ok {
+				add(g.Name, "ENCODING %d is already used by %s", g.Encoding, prev)
+			} else {
+				encodings[g.Encoding] = g.Name
+			}
+		}
+
+		w, h, x, y := g.BBX[0], g.BBX[1], g.BBX[2], g.BBX[3]
+		if w < 0 || h < 0 {
+			add(g.Name, "BBX has negative size %dx%d", w, h)
+			continue
+		}
+		if x < fbb[2] || y < fbb[3] || x+w > fbb[2]+fbb[0] || y+h > fbb[3]+fbb[1] {
+			add(g.Name, "BBX %v is out of FONTBOUNDINGBOX %v", g.BBX, fbb)
+		}
+
+		if len(g.Bitmap) != h {
+			add(g.Name, "BITMAP has %d rows but BBX height is %d", len(g.Bitmap), h)
+		}
+		rowLen := (w + 7) / 8 * 2
+		for i, row := range g.Bitmap {
+			if len(row) != rowLen {
+				add(g.Name, "BITMAP row %d has %d hex digits, want %d", i, len(row), rowLen)
+				break
+			}
+			if !isHex(row) {
+				add(g.Name, "BITMAP row %d is not hex: %q", i, row)
+				break
+			}
+		}
+	}
+	return problems
+}
+
+func isHex(s string) bool {
+	return strings.Trim(s, "0123456789ABCDEFabcdef") == ""
+}
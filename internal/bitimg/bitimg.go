@@ -1,28 +1,43 @@
-// Package bitimg provides 1-bit monochronme image.
 package bitimg
 
 import (
+	"errors"
 	"image"
 	"image/color"
 	"image/draw"
+	"math/bits"
+	"slices"
+	"strings"
 )
 
 type Bit bool
 
+// DefaultThreshold is the gray level which BitModel uses to convert colors:
+// a color is set when its gray level is greater than this.
+const DefaultThreshold = 127
+
 var BitModel = color.ModelFunc(func(c color.Color) color.Color {
-	return toBit(c)
+	return toBit(c, DefaultThreshold)
 })
 
-func toBit(c color.Color) Bit {
+func toBit(c color.Color, threshold uint8) Bit {
 	switch v := c.(type) {
 	case Bit:
 		return v
 	default:
 		g := color.GrayModel.Convert(c).(color.Gray)
-		return g.Y > 127
+		return g.Y > threshold
 	}
 }
 
+// String returns "1" for set, or "0" for unset.
+func (b Bit) String() string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
 func (b Bit) RGBA() (uint32, uint32, uint32, uint32) {
 	if b {
 		return 65535, 65535, 65535, 65535
@@ -31,32 +46,203 @@ func (b Bit) RGBA() (uint32, uint32, uint32, uint32) {
 }
 
 type Image struct {
-	buf  []byte
-	xn   int
-	rect image.Rectangle
+	buf       []byte
+	xn        int
+	rect      image.Rectangle
+	threshold uint8
 }
 
 func New(r image.Rectangle) *Image {
+	return NewWithThreshold(r, DefaultThreshold)
+}
+
+// NewWithThreshold returns a new image, which Set converts colors with the
+// threshold instead of DefaultThreshold: a color is set when its gray level
+// is greater than threshold. Drawing anti-aliased glyphs with a lower
+// threshold keeps thin strokes.
+func NewWithThreshold(r image.Rectangle, threshold uint8) *Image {
 	w, h := r.Dx(), r.Dy()
 	xn := (w + 7) / 8
 	buf := make([]byte, xn*h)
 	return &Image{
-		buf:  buf,
-		xn:   xn,
-		rect: r,
+		buf:       buf,
+		xn:        xn,
+		rect:      r,
+		threshold: threshold,
 	}
 }
 
+// NewFromImage returns a new image with the bounds of src, which has the
+// pixels of src set when their gray level is greater than threshold.
+// Transparent pixels are unset, as their gray level is 0.
+func NewFromImage(src image.Image, threshold uint8) *Image {
+	img := New(src.Bounds())
+	// Threshold never fails here, as both images have the same size.
+	_ = img.Threshold(src, threshold)
+	return img
+}
+
 func (img *Image) Xn() int { return img.xn }
 
+// Bytes returns the backing buffer of the image. It is unsafe: callers must
+// not modify the returned slice. Use BytesCopy to get a modifiable copy.
 func (img *Image) Bytes() []byte { return img.buf }
 
+// Row returns the row at y of the backing buffer, which is Xn bytes. Like
+// Bytes, callers must not modify the returned slice. It panics when y is out
+// of the bounds.
+func (img *Image) Row(y int) []byte {
+	if y < img.rect.Min.Y || y >= img.rect.Max.Y {
+		panic("bitimg: row out of bounds")
+	}
+	i := (y - img.rect.Min.Y) * img.xn
+	return img.buf[i : i+img.xn : i+img.xn]
+}
+
+// BytesCopy returns a copy of the backing buffer, which is safe to modify.
+func (img *Image) BytesCopy() []byte {
+	b := make([]byte, len(img.buf))
+	copy(b, img.buf)
+	return b
+}
+
+// Clone returns a copy of the image with its own buffer, the same bounds and
+// the same threshold, which is safe to modify.
+func (img *Image) Clone() *Image {
+	return &Image{buf: img.BytesCopy(), xn: img.xn, rect: img.rect, threshold: img.threshold}
+}
+
 func (img *Image) Clear() {
 	for i := range img.buf {
 		img.buf[i] = 0
 	}
 }
 
+// IsBlank reports whether all pixels of the image are unset.
+func (img *Image) IsBlank() bool {
+	for _, b := range img.buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Threshold converts the grayscale image gray into img in-place: a pixel is
+// set when its gray level is greater than t. gray must have the same size as
+// img.
+func (img *Image) Threshold(gray image.Image, t uint8) error {
+	gb := gray.Bounds()
+	if gb.Dx() != img.rect.Dx() || gb.Dy() != img.rect.Dy() {
+		return errors.New("bitimg: threshold source size mismatch")
+	}
+	img.Clear()
+	w, h := gb.Dx(), gb.Dy()
+	if g, ok := gray.(*image.Gray); ok {
+		// Fast path: read the pixels directly.
+		for y := 0; y < h; y++ {
+			row := g.Pix[g.PixOffset(gb.Min.X, gb.Min.Y+y):]
+			dst := img.buf[y*img.xn:]
+			for x := 0; x < w; x++ {
+				if row[x] > t {
+					dst[x/8] |= byte(0x80) >> (x % 8)
+				}
+			}
+		}
+		return nil
+	}
+	for y := 0; y < h; y++ {
+		dst := img.buf[y*img.xn:]
+		for x := 0; x < w; x++ {
+			if color.GrayModel.Convert(gray.At(gb.Min.X+x, gb.Min.Y+y)).(color.Gray).Y > t {
+				dst[x/8] |= byte(0x80) >> (x % 8)
+			}
+		}
+	}
+	return nil
+}
+
+// Invert flips all pixels of the image in-place. The padding bits at the end
+// of each row stay zero.
+func (img *Image) Invert() {
+	w := img.rect.Dx()
+	if img.xn == 0 {
+		return
+	}
+	last := byte(0xff)
+	if w%8 != 0 {
+		last = byte(0xff) << (8 - w%8)
+	}
+	for i := range img.buf {
+		img.buf[i] ^= 0xff
+		if i%img.xn == img.xn-1 {
+			img.buf[i] &= last
+		}
+	}
+}
+
+// InkBounds returns the smallest rectangle which contains all set pixels, or
+// an empty rectangle when the image is blank.
+func (img *Image) InkBounds() image.Rectangle {
+	ink := image.Rectangle{}
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		row := img.buf[y*img.xn : (y+1)*img.xn]
+		first := slices.IndexFunc(row, func(b byte) bool { return b != 0 })
+		if first < 0 {
+			continue
+		}
+		last := len(row) - 1
+		for row[last] == 0 {
+			last--
+		}
+		minX := first*8 + bits.LeadingZeros8(row[first])
+		maxX := min(last*8+8-bits.TrailingZeros8(row[last]), w)
+		ink = ink.Union(image.Rect(minX, y, maxX, y+1))
+	}
+	return ink.Add(img.rect.Min)
+}
+
+// Crop returns a copy of the part r of the image, which keeps the
+// coordinates of img: its bounds are r clipped by the bounds of img.
+func (img *Image) Crop(r image.Rectangle) *Image {
+	r = r.Intersect(img.rect)
+	dst := New(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if img.bit(x-img.rect.Min.X, y-img.rect.Min.Y) {
+				dst.setBit(x-r.Min.X, y-r.Min.Y, true)
+			}
+		}
+	}
+	return dst
+}
+
+// Trim returns a copy of the image without the blank rows and columns at the
+// edges. It keeps the coordinates of img, so the Min of its bounds is the
+// offset of the set pixels. A blank image is trimmed to its top left pixel,
+// as an image can't be smaller than 1x1.
+func (img *Image) Trim() *Image {
+	ink := img.InkBounds()
+	if ink.Empty() {
+		ink = image.Rectangle{Min: img.rect.Min, Max: img.rect.Min.Add(image.Pt(1, 1))}
+	}
+	return img.Crop(ink)
+}
+
+// Pad returns a new image extended to w x h pixels, padded with blank pixels
+// at the right and the bottom. The image is never cropped: w and h smaller
+// than the current size are ignored.
+func (img *Image) Pad(w, h int) *Image {
+	w = max(w, img.rect.Dx())
+	h = max(h, img.rect.Dy())
+	dst := New(image.Rect(img.rect.Min.X, img.rect.Min.Y, img.rect.Min.X+w, img.rect.Min.Y+h))
+	for y := 0; y < img.rect.Dy(); y++ {
+		copy(dst.buf[y*dst.xn:], img.buf[y*img.xn:(y+1)*img.xn])
+	}
+	return dst
+}
+
 var (
 	_ image.Image = (*Image)(nil)
 	_ draw.Image  = (*Image)(nil)
@@ -77,6 +263,9 @@ func (img *Image) address(x, y int) (index, shift int) {
 }
 
 func (img *Image) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(img.rect) {
+		return color.Black
+	}
 	idx, shift := img.address(x, y)
 	mask := byte(0x80) >> shift
 	if img.buf[idx]&mask != 0 {
@@ -85,12 +274,34 @@ func (img *Image) At(x, y int) color.Color {
 	return color.Black
 }
 
+// BitAt returns the pixel at (x, y) as Bit. Pixels outside the image are
+// unset.
+func (img *Image) BitAt(x, y int) Bit {
+	if !image.Pt(x, y).In(img.rect) {
+		return false
+	}
+	return Bit(img.bit(x-img.rect.Min.X, y-img.rect.Min.Y))
+}
+
+// String returns the pixels as lines of "1" for set and "0" for unset, one
+// line per row, each ended with a newline.
+func (img *Image) String() string {
+	b := &strings.Builder{}
+	for y := img.rect.Min.Y; y < img.rect.Max.Y; y++ {
+		for x := img.rect.Min.X; x < img.rect.Max.X; x++ {
+			b.WriteString(img.BitAt(x, y).String())
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
 func (img *Image) Set(x, y int, c color.Color) {
-	idx, shift := img.address(x, y)
-	if idx >= len(img.buf) {
+	if !image.Pt(x, y).In(img.rect) {
 		return
 	}
-	if toBit(c) {
+	idx, shift := img.address(x, y)
+	if toBit(c, img.threshold) {
 		img.buf[idx] |= byte(0x80) >> shift
 		return
 	}
@@ -0,0 +1,247 @@
+package bitimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestAddressBit(t *testing.T) {
+	for _, tc := range []struct {
+		rect         image.Rectangle
+		x, y         int // relative to rect.Min
+		index, shift int
+	}{
+		{image.Rect(0, 0, 10, 3), 0, 0, 0, 0},
+		{image.Rect(0, 0, 10, 3), 7, 0, 0, 7},
+		{image.Rect(0, 0, 10, 3), 8, 1, 3, 0},
+		{image.Rect(0, 0, 10, 3), 9, 2, 5, 1},
+		{image.Rect(0, 0, 1, 2), 0, 1, 1, 0},
+		{image.Rect(5, 5, 21, 21), 0, 0, 0, 0},
+		{image.Rect(5, 5, 21, 21), 7, 3, 6, 7},
+		{image.Rect(5, 5, 21, 21), 8, 3, 7, 0},
+		{image.Rect(5, 5, 21, 21), 15, 15, 31, 7},
+		{image.Rect(-3, 2, 20, 4), 0, 0, 0, 0},
+		{image.Rect(-3, 2, 20, 4), 7, 1, 3, 7},
+		{image.Rect(-3, 2, 20, 4), 8, 1, 4, 0},
+		{image.Rect(-3, 2, 20, 4), 22, 1, 5, 6},
+	} {
+		img := New(tc.rect)
+		ax, ay := tc.rect.Min.X+tc.x, tc.rect.Min.Y+tc.y
+		if index, shift := img.address(ax, ay); index != tc.index || shift != tc.shift {
+			t.Errorf("%v: address(%d, %d) = %d, %d; want %d, %d", tc.rect, ax, ay, index, shift, tc.index, tc.shift)
+		}
+
+		img.setBit(tc.x, tc.y, true)
+		if !img.bit(tc.x, tc.y) || !bool(img.BitAt(ax, ay)) {
+			t.Errorf("%v: pixel (%d, %d) is unset after setBit", tc.rect, tc.x, tc.y)
+		}
+		for i, b := range img.Bytes() {
+			want := byte(0)
+			if i == tc.index {
+				want = 0x80 >> tc.shift
+			}
+			if b != want {
+				t.Errorf("%v: byte %d = %#02x after setBit(%d, %d); want %#02x", tc.rect, i, b, tc.x, tc.y, want)
+			}
+		}
+		img.setBit(tc.x, tc.y, false)
+		if !img.IsBlank() {
+			t.Errorf("%v: image isn't blank after unsetting (%d, %d)", tc.rect, tc.x, tc.y)
+		}
+	}
+}
+
+func TestBitOutside(t *testing.T) {
+	img := New(image.Rect(5, 5, 15, 7))
+	for _, p := range []image.Point{{-1, 0}, {0, -1}, {10, 0}, {0, 2}} {
+		img.setBit(p.X, p.Y, true)
+		if img.bit(p.X, p.Y) {
+			t.Errorf("bit(%d, %d) = true outside the image", p.X, p.Y)
+		}
+	}
+	if !img.IsBlank() {
+		t.Errorf("setBit outside the image changed the pixels:\n%s", img)
+	}
+}
+
+// parseImage returns an image of the rows of "1" for set and "0" for unset
+// pixels, like String returns.
+func parseImage(t testing.TB, rows ...string) *Image {
+	t.Helper()
+	img := New(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		if len(row) != len(rows[0]) {
+			t.Fatalf("row %d has %d pixels; want %d", y, len(row), len(rows[0]))
+		}
+		for x, c := range row {
+			img.Set(x, y, Bit(c == '1'))
+		}
+	}
+	return img
+}
+
+// imageString returns the rows as String returns them.
+func imageString(rows ...string) string {
+	return strings.Join(rows, "\n") + "\n"
+}
+
+func TestNewFromImage(t *testing.T) {
+	rgba := image.NewRGBA(image.Rect(0, 0, 10, 4))
+	rgba.Set(1, 0, color.White)
+	// Transparent and half transparent white are darker than the threshold.
+	rgba.Set(2, 0, color.RGBA{})
+	rgba.Set(3, 0, color.RGBA{0x40, 0x40, 0x40, 0x40})
+	rgba.Set(4, 0, color.RGBA{0xc0, 0xc0, 0xc0, 0xc0})
+	rgba.Set(9, 3, color.White)
+	gray := image.NewGray(image.Rect(0, 0, 10, 4))
+	for _, p := range []image.Point{{1, 0}, {4, 0}, {9, 3}} {
+		gray.Set(p.X, p.Y, color.White)
+	}
+	for _, tc := range []struct {
+		name string
+		src  image.Image
+		want []string
+	}{
+		{"transparent", image.NewRGBA(image.Rect(0, 0, 3, 2)), []string{"000", "000"}},
+		{"RGBA", rgba, []string{
+			"0100100000",
+			"0000000000",
+			"0000000000",
+			"0000000001",
+		}},
+		{"RGBA sub-image", rgba.SubImage(image.Rect(1, 0, 10, 4)), []string{
+			"100100000",
+			"000000000",
+			"000000000",
+			"000000001",
+		}},
+		{"Gray sub-image", gray.SubImage(image.Rect(4, 3, 10, 4)), []string{
+			"000001",
+		}},
+		{"Gray sub-image at the top", gray.SubImage(image.Rect(0, 0, 5, 1)), []string{
+			"01001",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := NewFromImage(tc.src, DefaultThreshold)
+			if got, want := img.Bounds(), tc.src.Bounds(); got != want {
+				t.Errorf("bounds %v; want %v", got, want)
+			}
+			if got, want := img.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		gray      uint8
+		threshold uint8
+		want      Bit
+	}{
+		{126, DefaultThreshold, false},
+		{127, DefaultThreshold, false},
+		{128, DefaultThreshold, true},
+		{100, 99, true},
+		{99, 99, false},
+		{0, 0, false},
+		{1, 0, true},
+		{255, 254, true},
+		{255, 255, false},
+	} {
+		c := color.Gray{Y: tc.gray}
+		if got := toBit(c, tc.threshold); got != tc.want {
+			t.Errorf("toBit(%d, %d) = %v; want %v", tc.gray, tc.threshold, got, tc.want)
+		}
+		img := NewWithThreshold(image.Rect(0, 0, 1, 1), tc.threshold)
+		img.Set(0, 0, c)
+		if got := img.BitAt(0, 0); got != tc.want {
+			t.Errorf("NewWithThreshold(%d).Set(%d): pixel %v; want %v", tc.threshold, tc.gray, got, tc.want)
+		}
+		src := image.NewGray(image.Rect(0, 0, 1, 1))
+		src.SetGray(0, 0, c)
+		if got := NewFromImage(src, tc.threshold).BitAt(0, 0); got != tc.want {
+			t.Errorf("NewFromImage(%d, %d): pixel %v; want %v", tc.gray, tc.threshold, got, tc.want)
+		}
+	}
+	// New uses DefaultThreshold, which BitModel uses too.
+	img := New(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.Gray{Y: 127})
+	img.Set(1, 0, color.Gray{Y: 128})
+	if got, want := img.String(), "01\n"; got != want {
+		t.Errorf("New: %q; want %q", got, want)
+	}
+	if got := BitModel.Convert(color.Gray{Y: 128}); got != Bit(true) {
+		t.Errorf("BitModel of gray 128 is %v; want 1", got)
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := Bit(true).String() + Bit(false).String(); got != "10" {
+		t.Errorf("Bit strings %q; want \"10\"", got)
+	}
+	// A frame with the diagonal, in bounds not at the origin.
+	img := New(image.Rect(3, -2, 11, 6))
+	img.DrawBorderRect(img.Bounds(), true)
+	for i := 0; i < 8; i++ {
+		img.Set(3+i, -2+i, Bit(true))
+	}
+	want := "" +
+		"11111111\n" +
+		"11000001\n" +
+		"10100001\n" +
+		"10010001\n" +
+		"10001001\n" +
+		"10000101\n" +
+		"10000011\n" +
+		"11111111\n"
+	if got := img.String(); got != want {
+		t.Errorf("String():\n%s\nwant:\n%s", got, want)
+	}
+	if got := fmt.Sprint(img); got != want {
+		t.Errorf("fmt.Sprint doesn't use String:\n%s", got)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		rect   image.Rectangle
+		ink    []image.Rectangle
+		bounds image.Rectangle
+		want   []string
+	}{
+		{"blank", image.Rect(2, 3, 10, 8), nil, image.Rect(2, 3, 3, 4), []string{"0"}},
+		{"full", image.Rect(-1, -1, 9, 3), []image.Rectangle{image.Rect(-1, -1, 9, 3)}, image.Rect(-1, -1, 9, 3), []string{
+			"1111111111",
+			"1111111111",
+			"1111111111",
+			"1111111111",
+		}},
+		{"asymmetric", image.Rect(0, 0, 12, 10), []image.Rectangle{image.Rect(3, 2, 4, 7), image.Rect(4, 6, 9, 7)}, image.Rect(3, 2, 9, 7), []string{
+			"100000",
+			"100000",
+			"100000",
+			"100000",
+			"111111",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := New(tc.rect)
+			for _, r := range tc.ink {
+				img.DrawRect(r, true)
+			}
+			got := img.Trim()
+			if got.Bounds() != tc.bounds {
+				t.Errorf("bounds %v; want %v", got.Bounds(), tc.bounds)
+			}
+			if got, want := got.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
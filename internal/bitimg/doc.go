@@ -0,0 +1,25 @@
+/*
+Package bitimg provides 1-bit monochrome image.
+
+# Layout
+
+Image stores pixels in a row-major buffer, one bit per pixel. Each row
+occupies Xn bytes, which is the width rounded up to a multiple of 8 divided
+by 8: an image of 10 pixels width has Xn() == 2, and the 6 remaining bits of
+each row are padding, which is always zero.
+
+Bits are ordered MSB first: the leftmost pixel of a row is the bit 0x80 of
+its first byte, the next one is 0x40, and so on. This is the layout of the
+BITMAP rows of BDF, so Bytes can be written as hex per Xn bytes.
+
+Image implements image.Image and draw.Image with the color model BitModel.
+Colors are converted to Bit by their gray level: brighter than
+DefaultThreshold is set, or brighter than the threshold of NewWithThreshold.
+Pixels outside the bounds are ignored by Set and read as unset.
+
+# Examples
+
+Example_basic draws a diagonal line on an 8x8 image and reads back its bytes,
+and ExampleImage_Clear shows the padding bits of a 10 pixels wide image.
+*/
+package bitimg
@@ -0,0 +1,94 @@
+package bitimg
+
+import "image"
+
+// DrawRect sets all pixels within r to b, clipped to the image bounds.
+func (img *Image) DrawRect(r image.Rectangle, b Bit) {
+	r = r.Intersect(img.rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.setBit(x-img.rect.Min.X, y-img.rect.Min.Y, bool(b))
+		}
+	}
+}
+
+// DrawFilledRect is same as DrawRect, named for symmetry with DrawBorderRect.
+func (img *Image) DrawFilledRect(r image.Rectangle, b Bit) {
+	img.DrawRect(r, b)
+}
+
+// DrawBorderRect sets the pixels on the outline of r to b, clipped to the
+// image bounds.
+func (img *Image) DrawBorderRect(r image.Rectangle, b Bit) {
+	r = r.Canon()
+	if r.Empty() {
+		return
+	}
+	img.DrawRect(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1), b)
+	img.DrawRect(image.Rect(r.Min.X, r.Max.Y-1, r.Max.X, r.Max.Y), b)
+	img.DrawRect(image.Rect(r.Min.X, r.Min.Y, r.Min.X+1, r.Max.Y), b)
+	img.DrawRect(image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y), b)
+}
+
+// DrawLine sets the pixels on the line from (x0, y0) to (x1, y1) to b, using
+// Bresenham's algorithm. Pixels outside the image bounds are clipped.
+func (img *Image) DrawLine(x0, y0, x1, y1 int, b Bit) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.setBit(x0-img.rect.Min.X, y0-img.rect.Min.Y, bool(b))
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FloodFill sets b to the pixels 4-connected to (x, y) which have the same
+// value as (x, y). It uses an explicit stack instead of recursion.
+func (img *Image) FloodFill(x, y int, b Bit) {
+	if !image.Pt(x, y).In(img.rect) {
+		return
+	}
+	x, y = x-img.rect.Min.X, y-img.rect.Min.Y
+	target := img.bit(x, y)
+	if target == bool(b) {
+		return
+	}
+	w, h := img.rect.Dx(), img.rect.Dy()
+	stack := []image.Point{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p.X < 0 || p.Y < 0 || p.X >= w || p.Y >= h || img.bit(p.X, p.Y) != target {
+			continue
+		}
+		img.setBit(p.X, p.Y, bool(b))
+		stack = append(stack,
+			image.Pt(p.X+1, p.Y), image.Pt(p.X-1, p.Y),
+			image.Pt(p.X, p.Y+1), image.Pt(p.X, p.Y-1))
+	}
+}
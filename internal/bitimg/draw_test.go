@@ -0,0 +1,67 @@
+package bitimg
+
+import (
+	"image"
+	"testing"
+)
+
+func TestFloodFill(t *testing.T) {
+	ring := []string{
+		"0000000",
+		"0111110",
+		"0100010",
+		"0100010",
+		"0111110",
+		"0000000",
+	}
+	for _, tc := range []struct {
+		name string
+		x, y int
+		b    Bit
+		want []string
+	}{
+		{"inside", 2, 2, true, []string{
+			"0000000",
+			"0111110",
+			"0111110",
+			"0111110",
+			"0111110",
+			"0000000",
+		}},
+		{"outside", 0, 0, true, []string{
+			"1111111",
+			"1111111",
+			"1100011",
+			"1100011",
+			"1111111",
+			"1111111",
+		}},
+		{"ring", 1, 1, false, []string{
+			"0000000",
+			"0000000",
+			"0000000",
+			"0000000",
+			"0000000",
+			"0000000",
+		}},
+		{"same value", 2, 2, false, ring},
+		{"out of bounds", 7, 0, true, ring},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := parseImage(t, ring...)
+			img.FloodFill(tc.x, tc.y, tc.b)
+			if got, want := img.String(), imageString(tc.want...); got != want {
+				t.Errorf("FloodFill(%d, %d, %v):\n%s\nwant:\n%s", tc.x, tc.y, tc.b, got, want)
+			}
+		})
+	}
+}
+
+func TestFloodFillOrigin(t *testing.T) {
+	img := New(image.Rect(5, 5, 10, 10))
+	img.DrawBorderRect(image.Rect(6, 6, 9, 9), true)
+	img.FloodFill(7, 7, true)
+	if got := img.CountSetBits(); got != 9 {
+		t.Errorf("FloodFill inside a ring of bounds at (5, 5) sets %d pixels; want 9:\n%s", got, img)
+	}
+}
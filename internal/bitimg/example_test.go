@@ -0,0 +1,35 @@
+package bitimg_test
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// Create an 8x8 image, draw a diagonal line from top left to bottom right,
+// and read back its bytes.
+func Example_basic() {
+	img := bitimg.New(image.Rect(0, 0, 8, 8))
+	for i := 0; i < 8; i++ {
+		img.Set(i, i, bitimg.Bit(true))
+	}
+	fmt.Printf("%d % X\n", img.Xn(), img.Bytes())
+	// Output: 1 80 40 20 10 08 04 02 01
+}
+
+// A width which is not a multiple of 8 leaves padding bits at the end of each
+// row. Pixels are read back with BitAt, and Clear unsets all of them.
+func ExampleImage_Clear() {
+	img := bitimg.New(image.Rect(0, 0, 10, 2))
+	img.Set(0, 0, bitimg.Bit(true))
+	img.Set(9, 1, bitimg.Bit(true))
+	fmt.Println(img.Xn(), bool(img.BitAt(9, 1)), bool(img.BitAt(8, 1)))
+	fmt.Printf("% X\n", img.Bytes())
+	img.Clear()
+	fmt.Println(img.IsBlank())
+	// Output:
+	// 2 true false
+	// 80 00 00 40
+	// true
+}
@@ -0,0 +1,28 @@
+package bitimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// DrawGlyph draws the glyph of the rune r with the face, with the pen at (0,
+// ascent): the baseline is at y = ascent. The glyph is drawn over the current
+// pixels, so Clear the image beforehand to render a single glyph. It returns
+// an error without drawing when the face has no glyph for r.
+func (img *Image) DrawGlyph(face font.Face, r rune, ascent int) error {
+	if _, ok := face.GlyphAdvance(r); !ok {
+		return fmt.Errorf("bitimg: no glyph for U+%04X", r)
+	}
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot:  fixed.Point26_6{X: 0, Y: fixed.I(ascent)},
+	}
+	drawer.DrawString(string(r))
+	return nil
+}
@@ -0,0 +1,76 @@
+package bitimg
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// goRegularFace returns the face of Go Regular at 16 pixels.
+func goRegularFace(t testing.TB) font.Face {
+	t.Helper()
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 16, DPI: 72})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { face.Close() })
+	return face
+}
+
+func TestDrawGlyph(t *testing.T) {
+	face := goRegularFace(t)
+	const ascent = 14
+	for _, tc := range []struct {
+		r         rune
+		descender bool
+	}{
+		{'A', false},
+		{'x', false},
+		{'g', true},
+		{'p', true},
+	} {
+		img := New(image.Rect(0, 0, 16, 20))
+		if err := img.DrawGlyph(face, tc.r, ascent); err != nil {
+			t.Fatal(err)
+		}
+		ink := img.InkBounds()
+		if ink.Empty() {
+			t.Errorf("%c: nothing is drawn", tc.r)
+			continue
+		}
+		if got := ink.Max.Y > ascent; got != tc.descender {
+			t.Errorf("%c: ink %v goes below the baseline at %d: %t; want %t", tc.r, ink, ascent, got, tc.descender)
+		}
+		if !tc.descender && ink.Max.Y != ascent {
+			t.Errorf("%c: ink %v doesn't sit on the baseline at %d", tc.r, ink, ascent)
+		}
+	}
+}
+
+func TestDrawGlyphOver(t *testing.T) {
+	face := goRegularFace(t)
+	img := New(image.Rect(0, 0, 16, 20))
+	img.Set(15, 0, Bit(true))
+	if err := img.DrawGlyph(face, 'A', 14); err != nil {
+		t.Fatal(err)
+	}
+	if !img.BitAt(15, 0) {
+		t.Errorf("DrawGlyph cleared a pixel outside the glyph")
+	}
+
+	// Go Regular has no CJK glyphs.
+	img.Clear()
+	if err := img.DrawGlyph(face, '中', 14); err == nil {
+		t.Errorf("DrawGlyph of U+4E2D: got no error")
+	}
+	if !img.IsBlank() {
+		t.Errorf("DrawGlyph drew a missing glyph:\n%s", img)
+	}
+}
@@ -0,0 +1,81 @@
+package bitimg
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"image"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Image)(nil)
+	_ encoding.BinaryUnmarshaler = (*Image)(nil)
+	_ json.Marshaler             = (*Image)(nil)
+	_ json.Unmarshaler           = (*Image)(nil)
+)
+
+// binaryHeaderSize is the size of the header of the binary format: width,
+// height and bytes per row (xn) as big endian uint32.
+const binaryHeaderSize = 12
+
+// MarshalBinary encodes the image as width, height, xn (big endian uint32)
+// and the raw bytes. The origin of the bounds is not preserved.
+func (img *Image) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binaryHeaderSize, binaryHeaderSize+len(img.buf))
+	binary.BigEndian.PutUint32(b[0:], uint32(img.rect.Dx()))
+	binary.BigEndian.PutUint32(b[4:], uint32(img.rect.Dy()))
+	binary.BigEndian.PutUint32(b[8:], uint32(img.xn))
+	return append(b, img.buf...), nil
+}
+
+// UnmarshalBinary decodes the data written by MarshalBinary into the image.
+// xn must be (width+7)/8, as rows are padded only to bytes.
+func (img *Image) UnmarshalBinary(data []byte) error {
+	if len(data) < binaryHeaderSize {
+		return errors.New("bitimg: binary data too short")
+	}
+	w := binary.BigEndian.Uint32(data[0:])
+	h := binary.BigEndian.Uint32(data[4:])
+	xn := uint64(binary.BigEndian.Uint32(data[8:]))
+	if xn != (uint64(w)+7)/8 {
+		return errors.New("bitimg: binary row size mismatch")
+	}
+	if uint64(len(data)-binaryHeaderSize) != xn*uint64(h) {
+		return errors.New("bitimg: binary data size mismatch")
+	}
+	*img = *New(image.Rect(0, 0, int(w), int(h)))
+	copy(img.buf, data[binaryHeaderSize:])
+	return nil
+}
+
+// jsonImage is the JSON representation of Image. Data is encoded as Base64 by
+// encoding/json.
+type jsonImage struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Data   []byte `json:"data"`
+}
+
+// MarshalJSON encodes the image as {"width":W,"height":H,"data":"<base64>"}.
+func (img *Image) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonImage{
+		Width:  img.rect.Dx(),
+		Height: img.rect.Dy(),
+		Data:   img.buf,
+	})
+}
+
+// UnmarshalJSON decodes the data written by MarshalJSON into the image.
+func (img *Image) UnmarshalJSON(data []byte) error {
+	var v jsonImage
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Width < 0 || v.Height < 0 || len(v.Data) != (v.Width+7)/8*v.Height {
+		return errors.New("bitimg: JSON data size mismatch")
+	}
+	*img = *New(image.Rect(0, 0, v.Width, v.Height))
+	copy(img.buf, v.Data)
+	return nil
+}
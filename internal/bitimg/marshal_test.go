@@ -0,0 +1,89 @@
+package bitimg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"testing"
+)
+
+// marshalTestImages are images of widths with and without padding bits.
+func marshalTestImages(t *testing.T) map[string]*Image {
+	return map[string]*Image{
+		"empty": New(image.Rectangle{}),
+		"8x1":   parseImage(t, "10011001"),
+		"10x3":  parseImage(t, "1000000001", "0110000110", "0001111000"),
+		"17x2":  parseImage(t, "10000000100000001", "01111111011111110"),
+		"blank": New(image.Rect(0, 0, 13, 5)),
+		"diagonal": func() *Image {
+			img := New(image.Rect(0, 0, 9, 9))
+			img.DrawLine(0, 0, 8, 8, true)
+			return img
+		}(),
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for name, img := range marshalTestImages(t) {
+		data, err := img.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := binaryHeaderSize + len(img.Bytes()); len(data) != want {
+			t.Errorf("%s: %d bytes; want %d", name, len(data), want)
+		}
+		b := img.Bounds()
+		if got, want := [3]uint32{binary.BigEndian.Uint32(data), binary.BigEndian.Uint32(data[4:]), binary.BigEndian.Uint32(data[8:])}, [3]uint32{uint32(b.Dx()), uint32(b.Dy()), uint32(img.Xn())}; got != want {
+			t.Errorf("%s: header %v; want width, height and xn %v", name, got, want)
+		}
+		got := &Image{}
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !got.Equal(img) {
+			t.Errorf("%s: round trip:\n%s\nwant:\n%s", name, got, img)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for name, img := range marshalTestImages(t) {
+		data, err := json.Marshal(img)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := &Image{}
+		if err := json.Unmarshal(data, got); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !got.Equal(img) {
+			t.Errorf("%s: round trip of %s:\n%s\nwant:\n%s", name, data, got, img)
+		}
+	}
+}
+
+func TestUnmarshalError(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{0, 0, 0, 10},
+		{0, 0, 0, 10, 0, 0, 0, 2},
+		{0, 0, 0, 10, 0, 0, 0, 2, 0, 0, 0, 2, 0, 0, 0},
+		{0, 0, 0, 10, 0, 0, 0, 2, 0, 0, 0, 2, 0, 0, 0, 0, 0},
+		// xn doesn't agree with the width.
+		{0, 0, 0, 10, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0},
+		{0, 0, 0, 10, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0, 0},
+	} {
+		if err := (&Image{}).UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(% x): got no error", data)
+		}
+	}
+	for _, data := range []string{
+		`{"width":10,"height":2,"data":"AAAA"}`,
+		`{"width":-8,"height":1,"data":""}`,
+		`[]`,
+	} {
+		if err := json.Unmarshal([]byte(data), &Image{}); err == nil {
+			t.Errorf("UnmarshalJSON(%s): got no error", data)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package bitimg
+
+// cross is the 3x3 cross structuring element: the pixel itself and its 4
+// direct neighbors.
+var cross = [...][2]int{{0, 0}, {0, -1}, {0, 1}, {1, 0}, {-1, 0}}
+
+// Erosion3x3 returns a new image in which each pixel is set if and only if
+// the pixel and its 4 direct neighbors (N, S, E and W) are set in img. Pixels
+// outside the image count as unset, so set pixels on the edges are removed.
+func (img *Image) Erosion3x3() *Image {
+	dst := New(img.rect)
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			set := true
+			for _, d := range cross {
+				if !img.bit(x+d[0], y+d[1]) {
+					set = false
+					break
+				}
+			}
+			if set {
+				dst.setBit(x, y, true)
+			}
+		}
+	}
+	return dst
+}
+
+// Dilation3x3 returns a new image in which each pixel is set if any of the
+// pixel and its 4 direct neighbors (N, S, E and W) is set in img. It is the
+// counterpart of Erosion3x3: an erosion followed by a dilation is an opening,
+// and a dilation followed by an erosion is a closing.
+func (img *Image) Dilation3x3() *Image {
+	dst := New(img.rect)
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			for _, d := range cross {
+				if img.bit(x+d[0], y+d[1]) {
+					dst.setBit(x, y, true)
+					break
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// Dilate returns a new image in which each pixel is set if any pixel within
+// the distance radius from it is set in img: each set pixel expands to a disk
+// of the radius. A radius less than 1 returns a copy.
+func (img *Image) Dilate(radius float64) *Image {
+	var disk [][2]int
+	r := int(radius)
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if float64(dx*dx+dy*dy) <= radius*radius {
+				disk = append(disk, [2]int{dx, dy})
+			}
+		}
+	}
+	dst := NewWithThreshold(img.rect, img.threshold)
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !img.bit(x, y) {
+				continue
+			}
+			for _, d := range disk {
+				dst.setBit(x+d[0], y+d[1], true)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,112 @@
+package bitimg
+
+import "testing"
+
+func TestErosion3x3(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		src, want []string
+	}{
+		{"block", []string{
+			"00000",
+			"01110",
+			"01110",
+			"01110",
+			"00000",
+		}, []string{
+			"00000",
+			"00000",
+			"00100",
+			"00000",
+			"00000",
+		}},
+		// Pixels outside the image count as unset.
+		{"full", []string{
+			"111",
+			"111",
+			"111",
+		}, []string{
+			"000",
+			"010",
+			"000",
+		}},
+		{"plus", []string{
+			"010",
+			"111",
+			"010",
+		}, []string{
+			"000",
+			"010",
+			"000",
+		}},
+		{"line", []string{
+			"000",
+			"111",
+			"000",
+		}, []string{
+			"000",
+			"000",
+			"000",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseImage(t, tc.src...).Erosion3x3()
+			if got, want := got.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDilation3x3(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		src, want []string
+	}{
+		{"pixel", []string{
+			"00000",
+			"00000",
+			"00100",
+			"00000",
+			"00000",
+		}, []string{
+			"00000",
+			"00100",
+			"01110",
+			"00100",
+			"00000",
+		}},
+		// The plus is clipped by the bounds.
+		{"corner", []string{
+			"100",
+			"000",
+			"000",
+		}, []string{
+			"110",
+			"100",
+			"000",
+		}},
+		{"blank", []string{
+			"000",
+			"000",
+		}, []string{
+			"000",
+			"000",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseImage(t, tc.src...).Dilation3x3()
+			if got, want := got.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDilationErosion(t *testing.T) {
+	// Dilation3x3 of a pixel is the cross, which Erosion3x3 reverts.
+	src := parseImage(t, "00000", "00000", "00100", "00000", "00000")
+	if got := src.Dilation3x3().Erosion3x3(); !got.Equal(src) {
+		t.Errorf("closing of a pixel:\n%s\nwant:\n%s", got, src)
+	}
+}
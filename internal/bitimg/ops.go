@@ -0,0 +1,240 @@
+package bitimg
+
+import (
+	"errors"
+	"image"
+	"math/bits"
+)
+
+// bit returns the pixel at (x, y) relative to the origin of the bounds.
+// Pixels outside the image are unset.
+func (img *Image) bit(x, y int) bool {
+	if x < 0 || y < 0 || x >= img.rect.Dx() || y >= img.rect.Dy() {
+		return false
+	}
+	return img.buf[y*img.xn+x/8]&(byte(0x80)>>(x%8)) != 0
+}
+
+// setBit sets the pixel at (x, y) relative to the origin of the bounds.
+// Pixels outside the image are ignored.
+func (img *Image) setBit(x, y int, b bool) {
+	if x < 0 || y < 0 || x >= img.rect.Dx() || y >= img.rect.Dy() {
+		return
+	}
+	mask := byte(0x80) >> (x % 8)
+	if b {
+		img.buf[y*img.xn+x/8] |= mask
+		return
+	}
+	img.buf[y*img.xn+x/8] &^= mask
+}
+
+// Shift translates the content of the image by (dx, dy) in-place. Pixels
+// moved out of the canvas are lost, and vacated pixels become unset.
+func (img *Image) Shift(dx, dy int) {
+	orig := img.Clone()
+	img.Clear()
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if orig.bit(x-dx, y-dy) {
+				img.setBit(x, y, true)
+			}
+		}
+	}
+}
+
+// ErrSizeMismatch is returned by operations on two images of different sizes.
+var ErrSizeMismatch = errors.New("bitimg: image size mismatch")
+
+// Or sets the pixels of img which are set in src. Both images must have the
+// same size.
+func (img *Image) Or(src *Image) error {
+	if img.rect.Size() != src.rect.Size() {
+		return ErrSizeMismatch
+	}
+	for i, b := range src.buf {
+		img.buf[i] |= b
+	}
+	return nil
+}
+
+// And unsets the pixels of img which are unset in src. Both images must have
+// the same size.
+func (img *Image) And(src *Image) error {
+	if img.rect.Size() != src.rect.Size() {
+		return ErrSizeMismatch
+	}
+	for i, b := range src.buf {
+		img.buf[i] &= b
+	}
+	return nil
+}
+
+// Xor flips the pixels of img which are set in src. Both images must have
+// the same size.
+func (img *Image) Xor(src *Image) error {
+	if img.rect.Size() != src.rect.Size() {
+		return ErrSizeMismatch
+	}
+	for i, b := range src.buf {
+		img.buf[i] ^= b
+	}
+	return nil
+}
+
+// Diff returns the number of pixels which differ between img and other. Both
+// images must have the same size.
+func (img *Image) Diff(other *Image) (int, error) {
+	if img.rect.Size() != other.rect.Size() {
+		return 0, ErrSizeMismatch
+	}
+	n := 0
+	last := img.lastMask()
+	for i, b := range img.buf {
+		d := b ^ other.buf[i]
+		if i%img.xn == img.xn-1 {
+			d &= last
+		}
+		n += bits.OnesCount8(d)
+	}
+	return n, nil
+}
+
+// Equal reports whether img and other have the same bounds and the same
+// pixels.
+func (img *Image) Equal(other *Image) bool {
+	if img.rect != other.rect {
+		return false
+	}
+	// The padding bits may be set by UnmarshalBinary and UnmarshalJSON, so
+	// they are masked.
+	last := img.lastMask()
+	for i, b := range img.buf {
+		d := b ^ other.buf[i]
+		if i%img.xn == img.xn-1 {
+			d &= last
+		}
+		if d != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// lastMask returns the mask of the pixels in the last byte of each row,
+// without the padding bits.
+func (img *Image) lastMask() byte {
+	if w := img.rect.Dx(); w%8 != 0 {
+		return byte(0xff) << (8 - w%8)
+	}
+	return 0xff
+}
+
+// DiffImage returns a new image with the bounds of img, which has the pixels
+// set where img and other differ. Pixels outside other are read as unset, so
+// the images may have different bounds, unlike Diff.
+func (img *Image) DiffImage(other *Image) *Image {
+	dst := New(img.rect)
+	for y := img.rect.Min.Y; y < img.rect.Max.Y; y++ {
+		for x := img.rect.Min.X; x < img.rect.Max.X; x++ {
+			if img.BitAt(x, y) != other.BitAt(x, y) {
+				dst.Set(x, y, Bit(true))
+			}
+		}
+	}
+	return dst
+}
+
+// CountSetBits returns the number of set pixels. Padding bits at the end of
+// each row are excluded.
+func (img *Image) CountSetBits() int {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	if w <= 0 {
+		return 0
+	}
+	full, rest := w/8, w%8
+	last := byte(0xff) << (8 - rest)
+	n := 0
+	for y := 0; y < h; y++ {
+		row := img.buf[y*img.xn : (y+1)*img.xn]
+		for _, b := range row[:full] {
+			n += bits.OnesCount8(b)
+		}
+		if rest > 0 {
+			n += bits.OnesCount8(row[full] & last)
+		}
+	}
+	return n
+}
+
+// CountTransitions returns the total number of 0->1 and 1->0 transitions
+// between adjacent pixels in all rows. Padding bits are excluded. A low count
+// indicates a sparse glyph, which compresses well with RLE.
+func (img *Image) CountTransitions() int {
+	n := 0
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		prev := img.bit(0, y)
+		for x := 1; x < w; x++ {
+			cur := img.bit(x, y)
+			if cur != prev {
+				n++
+			}
+			prev = cur
+		}
+	}
+	return n
+}
+
+// Connected returns the 4-connected components of the set pixels. Each
+// component is a slice of the points in the image coordinates, and the
+// components are ordered by their first pixel in the row-major order.
+func (img *Image) Connected() [][]image.Point {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	seen := make([]bool, w*h)
+	var components [][]image.Point
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if seen[y*w+x] || !img.bit(x, y) {
+				continue
+			}
+			// Breadth-first search from (x, y).
+			seen[y*w+x] = true
+			queue := []image.Point{{x, y}}
+			var comp []image.Point
+			for len(queue) > 0 {
+				p := queue[0]
+				queue = queue[1:]
+				comp = append(comp, p.Add(img.rect.Min))
+				for _, q := range [...]image.Point{{p.X + 1, p.Y}, {p.X - 1, p.Y}, {p.X, p.Y + 1}, {p.X, p.Y - 1}} {
+					if q.X < 0 || q.Y < 0 || q.X >= w || q.Y >= h || seen[q.Y*w+q.X] || !img.bit(q.X, q.Y) {
+						continue
+					}
+					seen[q.Y*w+q.X] = true
+					queue = append(queue, q)
+				}
+			}
+			components = append(components, comp)
+		}
+	}
+	return components
+}
+
+// Moments returns the zeroth and first moments of the set pixels: m00 is the
+// number of set pixels, and m10 and m01 are the sums of their x and y
+// coordinates. The centroid is (m10/m00, m01/m00).
+func (img *Image) Moments() (m00, m10, m01 float64) {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !img.bit(x, y) {
+				continue
+			}
+			m00++
+			m10 += float64(img.rect.Min.X + x)
+			m01 += float64(img.rect.Min.Y + y)
+		}
+	}
+	return m00, m10, m01
+}
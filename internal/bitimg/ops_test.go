@@ -0,0 +1,212 @@
+package bitimg
+
+import (
+	"errors"
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestEqualDiffIgnorePadding(t *testing.T) {
+	a := New(image.Rect(0, 0, 10, 2))
+	a.Set(9, 1, Bit(true))
+	b := &Image{}
+	// The padding bits of the rows are set in the binary data.
+	if err := b.UnmarshalBinary([]byte{0, 0, 0, 10, 0, 0, 0, 2, 0, 0, 0, 2, 0x00, 0x3f, 0x00, 0x7f}); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Equal(b) {
+		t.Errorf("Equal() = false; want true for images which differ only in padding")
+	}
+	if n, err := a.Diff(b); err != nil || n != 0 {
+		t.Errorf("Diff() = %d, %v; want 0, nil", n, err)
+	}
+	b.Set(0, 0, Bit(true))
+	if a.Equal(b) {
+		t.Errorf("Equal() = true; want false")
+	}
+	if n, err := a.Diff(b); err != nil || n != 1 {
+		t.Errorf("Diff() = %d, %v; want 1, nil", n, err)
+	}
+}
+
+func TestShift(t *testing.T) {
+	src := []string{
+		"1100000001",
+		"0010000000",
+		"0000000011",
+	}
+	for _, tc := range []struct {
+		name   string
+		dx, dy int
+		want   []string
+	}{
+		{"none", 0, 0, src},
+		{"right", 1, 0, []string{
+			"0110000000",
+			"0001000000",
+			"0000000001",
+		}},
+		{"left", -2, 0, []string{
+			"0000000100",
+			"1000000000",
+			"0000001100",
+		}},
+		{"down", 0, 1, []string{
+			"0000000000",
+			"1100000001",
+			"0010000000",
+		}},
+		{"up left", -1, -1, []string{
+			"0100000000",
+			"0000000110",
+			"0000000000",
+		}},
+		{"right beyond width", 10, 0, []string{
+			"0000000000",
+			"0000000000",
+			"0000000000",
+		}},
+		{"left beyond width", -11, 0, []string{
+			"0000000000",
+			"0000000000",
+			"0000000000",
+		}},
+		{"down beyond height", 0, 3, []string{
+			"0000000000",
+			"0000000000",
+			"0000000000",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := parseImage(t, src...)
+			img.Shift(tc.dx, tc.dy)
+			if got, want := img.String(), imageString(tc.want...); got != want {
+				t.Errorf("Shift(%d, %d):\n%s\nwant:\n%s", tc.dx, tc.dy, got, want)
+			}
+			for y := 0; y < 3; y++ {
+				if b := img.Row(y)[1]; b&^img.lastMask() != 0 {
+					t.Errorf("Shift(%d, %d): row %d has the padding bits %#02x set", tc.dx, tc.dy, y, b&^img.lastMask())
+				}
+			}
+		})
+	}
+}
+
+func TestConnected(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		rows  []string
+		sizes []int
+	}{
+		{"blank", []string{"000", "000"}, nil},
+		{"i", []string{
+			"00011000",
+			"00011000",
+			"00000000",
+			"00111000",
+			"00011000",
+			"00011000",
+			"00011000",
+			"00111100",
+		}, []int{4, 13}},
+		{"rectangle", []string{
+			"00000000",
+			"01111110",
+			"01000010",
+			"01000010",
+			"01111110",
+			"00000000",
+		}, []int{16}},
+		// Diagonal neighbors aren't 4-connected.
+		{"diagonal", []string{
+			"100",
+			"010",
+			"001",
+		}, []int{1, 1, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sizes []int
+			for _, c := range parseImage(t, tc.rows...).Connected() {
+				sizes = append(sizes, len(c))
+			}
+			if !slices.Equal(sizes, tc.sizes) {
+				t.Errorf("sizes of the components %v; want %v", sizes, tc.sizes)
+			}
+		})
+	}
+}
+
+func TestConnectedOrigin(t *testing.T) {
+	img := New(image.Rect(5, 5, 10, 10))
+	img.Set(6, 7, Bit(true))
+	comps := img.Connected()
+	if len(comps) != 1 || !slices.Equal(comps[0], []image.Point{{6, 7}}) {
+		t.Errorf("Connected() = %v; want [[(6,7)]] in the image coordinates", comps)
+	}
+}
+
+func TestMoments(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		rect, ink     image.Rectangle
+		m00, m10, m01 float64
+	}{
+		{"blank", image.Rect(0, 0, 8, 8), image.Rectangle{}, 0, 0, 0},
+		// The centroid of the square is on the diagonal.
+		{"square", image.Rect(0, 0, 8, 8), image.Rect(2, 2, 5, 5), 9, 27, 27},
+		{"bar", image.Rect(0, 0, 8, 8), image.Rect(0, 1, 4, 2), 4, 6, 4},
+		{"origin", image.Rect(10, 20, 18, 28), image.Rect(10, 20, 12, 22), 4, 42, 82},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := New(tc.rect)
+			img.DrawRect(tc.ink, true)
+			m00, m10, m01 := img.Moments()
+			if m00 != tc.m00 || m10 != tc.m10 || m01 != tc.m01 {
+				t.Errorf("Moments() = %v, %v, %v; want %v, %v, %v", m00, m10, m01, tc.m00, tc.m10, tc.m01)
+			}
+		})
+	}
+}
+
+func TestOrAndXor(t *testing.T) {
+	a := []string{
+		"1100110011",
+		"0000111100",
+	}
+	b := []string{
+		"1010101010",
+		"0101010101",
+	}
+	for _, tc := range []struct {
+		name string
+		op   func(img, src *Image) error
+		want []string
+	}{
+		{"Or", (*Image).Or, []string{
+			"1110111011",
+			"0101111101",
+		}},
+		{"And", (*Image).And, []string{
+			"1000100010",
+			"0000010100",
+		}},
+		{"Xor", (*Image).Xor, []string{
+			"0110011001",
+			"0101101001",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := parseImage(t, a...)
+			if err := tc.op(img, parseImage(t, b...)); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := img.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+			if err := tc.op(img, New(image.Rect(0, 0, 10, 3))); !errors.Is(err, ErrSizeMismatch) {
+				t.Errorf("%s of a different size: got %v; want ErrSizeMismatch", tc.name, err)
+			}
+		})
+	}
+}
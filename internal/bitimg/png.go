@@ -0,0 +1,36 @@
+package bitimg
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// pngPalette maps unset pixels to black and set pixels to white, as At does.
+var pngPalette = color.Palette{color.Black, color.White}
+
+// EncodePNG writes the image to w as a 1-bit paletted PNG.
+func (img *Image) EncodePNG(w io.Writer) error {
+	dst := image.NewPaletted(img.rect, pngPalette)
+	w0, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w0; x++ {
+			if img.bit(x, y) {
+				dst.Pix[y*dst.Stride+x] = 1
+			}
+		}
+	}
+	return png.Encode(w, dst)
+}
+
+// DecodePNG reads a PNG image from r, and returns an image which has the
+// pixels set when their gray level is greater than DefaultThreshold, as
+// toBit does. It round-trips the images written by EncodePNG.
+func DecodePNG(r io.Reader) (*Image, error) {
+	src, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromImage(src, DefaultThreshold), nil
+}
@@ -0,0 +1,117 @@
+package bitimg
+
+import (
+	"image"
+	"math"
+)
+
+// Interpolation is a mode of interpolation for Resize.
+type Interpolation int
+
+const (
+	// NearestNeighbor takes the nearest source pixel.
+	NearestNeighbor Interpolation = iota
+	// Bilinear interpolates the four nearest source pixels as grayscale, and
+	// then thresholds the result.
+	Bilinear
+)
+
+// Resize returns a new image of newW x newH pixels, scaled with the
+// interpolation mode. threshold is used by the modes which go through
+// grayscale: a pixel is set when its interpolated level (0-255) is greater
+// than threshold.
+func (img *Image) Resize(newW, newH int, mode Interpolation, threshold uint8) *Image {
+	o := img.rect.Min
+	dst := New(image.Rect(o.X, o.Y, o.X+newW, o.Y+newH))
+	w, h := img.rect.Dx(), img.rect.Dy()
+	if w == 0 || h == 0 || newW <= 0 || newH <= 0 {
+		return dst
+	}
+	sx := float64(w) / float64(newW)
+	sy := float64(h) / float64(newH)
+	level := func(x, y int) float64 {
+		x = max(0, min(x, w-1))
+		y = max(0, min(y, h-1))
+		if img.bit(x, y) {
+			return 255
+		}
+		return 0
+	}
+	for y := 0; y < newH; y++ {
+		for x := 0; x < newW; x++ {
+			// Map the center of the destination pixel to the source.
+			fx := (float64(x)+0.5)*sx - 0.5
+			fy := (float64(y)+0.5)*sy - 0.5
+			var set bool
+			switch mode {
+			case Bilinear:
+				x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+				ax, ay := fx-float64(x0), fy-float64(y0)
+				v := level(x0, y0)*(1-ax)*(1-ay) +
+					level(x0+1, y0)*ax*(1-ay) +
+					level(x0, y0+1)*(1-ax)*ay +
+					level(x0+1, y0+1)*ax*ay
+				set = v > float64(threshold)
+			default:
+				set = level(int((float64(x)+0.5)*sx), int((float64(y)+0.5)*sy)) > 0
+			}
+			dst.setBit(x, y, set)
+		}
+	}
+	return dst
+}
+
+// PixelDouble returns a new image of twice the width and the height of img,
+// which has each pixel expanded to a 2x2 block. The origin of the bounds is
+// kept, as Resize does.
+func PixelDouble(img *Image) *Image {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	o := img.rect.Min
+	dst := New(image.Rect(o.X, o.Y, o.X+2*w, o.Y+2*h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(2*x, 2*y, true)
+				dst.setBit(2*x+1, 2*y, true)
+			}
+		}
+		// The odd rows are the copies of the even ones.
+		copy(dst.buf[(2*y+1)*dst.xn:(2*y+2)*dst.xn], dst.buf[2*y*dst.xn:(2*y+1)*dst.xn])
+	}
+	return dst
+}
+
+// ResizeBilinear returns a new image of newW x newH pixels, scaled by
+// bilinear interpolation and thresholded back to 1-bit.
+func (img *Image) ResizeBilinear(newW, newH int, threshold uint8) *Image {
+	return img.Resize(newW, newH, Bilinear, threshold)
+}
+
+// Downsample returns a new image reduced by factor, using majority vote: each
+// output pixel is set when more than half of the source pixels in its
+// factor x factor block are set. Blocks at the right and bottom edges may be
+// partial, and then vote with the pixels they have.
+func (img *Image) Downsample(factor int) *Image {
+	if factor <= 1 {
+		return img.Resize(img.rect.Dx(), img.rect.Dy(), NearestNeighbor, 0)
+	}
+	w, h := img.rect.Dx(), img.rect.Dy()
+	newW, newH := (w+factor-1)/factor, (h+factor-1)/factor
+	o := img.rect.Min
+	dst := New(image.Rect(o.X, o.Y, o.X+newW, o.Y+newH))
+	for y := 0; y < newH; y++ {
+		for x := 0; x < newW; x++ {
+			set, total := 0, 0
+			for by := y * factor; by < min((y+1)*factor, h); by++ {
+				for bx := x * factor; bx < min((x+1)*factor, w); bx++ {
+					total++
+					if img.bit(bx, by) {
+						set++
+					}
+				}
+			}
+			dst.setBit(x, y, set > total/2)
+		}
+	}
+	return dst
+}
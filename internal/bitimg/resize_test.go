@@ -0,0 +1,66 @@
+package bitimg
+
+import "testing"
+
+func TestDownsample(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		src    []string
+		factor int
+		want   []string
+	}{
+		{"checkerboard of blocks", []string{
+			"1100",
+			"1100",
+			"0011",
+			"0011",
+		}, 2, []string{
+			"10",
+			"01",
+		}},
+		// Half of each block is set, which isn't the majority.
+		{"checkerboard of pixels", []string{
+			"1010",
+			"0101",
+			"1010",
+			"0101",
+		}, 2, []string{
+			"00",
+			"00",
+		}},
+		{"majority", []string{
+			"1101",
+			"1000",
+			"0111",
+			"0011",
+		}, 2, []string{
+			"10",
+			"01",
+		}},
+		{"partial blocks", []string{
+			"11011",
+			"11011",
+			"00000",
+			"00000",
+			"10001",
+		}, 2, []string{
+			"101",
+			"000",
+			"001",
+		}},
+		{"factor 1", []string{
+			"10",
+			"01",
+		}, 1, []string{
+			"10",
+			"01",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseImage(t, tc.src...).Downsample(tc.factor)
+			if got, want := got.String(), imageString(tc.want...); got != want {
+				t.Errorf("Downsample(%d):\n%s\nwant:\n%s", tc.factor, got, want)
+			}
+		})
+	}
+}
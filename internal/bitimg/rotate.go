@@ -0,0 +1,129 @@
+package bitimg
+
+import (
+	"image"
+	"math"
+)
+
+// RotateArbitrary returns a new image rotated counterclockwise by degrees
+// around the center of the image, with nearest neighbor sampling. The new
+// image is enlarged to contain the whole rotated source, and its pixels which
+// come from outside the source are filled with bg.
+func (img *Image) RotateArbitrary(degrees float64, bg Bit) *Image {
+	rad := degrees * math.Pi / 180
+	sin, cos := math.Sincos(rad)
+	w, h := float64(img.rect.Dx()), float64(img.rect.Dy())
+	// Tolerate rounding errors, to keep the size for multiples of 90 degrees.
+	const eps = 1e-9
+	newW := int(math.Ceil(math.Abs(w*cos) + math.Abs(h*sin) - eps))
+	newH := int(math.Ceil(math.Abs(w*sin) + math.Abs(h*cos) - eps))
+	o := img.rect.Min
+	dst := New(image.Rect(o.X, o.Y, o.X+newW, o.Y+newH))
+	for y := 0; y < newH; y++ {
+		for x := 0; x < newW; x++ {
+			// Map the center of the destination pixel back to the source.
+			dx := float64(x) + 0.5 - float64(newW)/2
+			dy := float64(y) + 0.5 - float64(newH)/2
+			sx := math.Floor(dx*cos - dy*sin + w/2)
+			sy := math.Floor(dx*sin + dy*cos + h/2)
+			b := bool(bg)
+			if sx >= 0 && sy >= 0 && sx < w && sy < h {
+				b = img.bit(int(sx), int(sy))
+			}
+			dst.setBit(x, y, b)
+		}
+	}
+	return dst
+}
+
+// FlipHorizontal returns a new image mirrored left to right. Padding bits at
+// the end of the rows stay unset.
+func (img *Image) FlipHorizontal() *Image {
+	dst := New(img.rect)
+	w, h := img.rect.Dx(), img.rect.Dy()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(w-1-x, y, true)
+			}
+		}
+	}
+	return dst
+}
+
+// FlipVertical returns a new image mirrored top to bottom.
+func (img *Image) FlipVertical() *Image {
+	dst := New(img.rect)
+	h := img.rect.Dy()
+	for y := 0; y < h; y++ {
+		copy(dst.buf[(h-1-y)*img.xn:(h-y)*img.xn], img.buf[y*img.xn:(y+1)*img.xn])
+	}
+	return dst
+}
+
+// Italicize returns a new image sheared to the right by slant, which must not
+// be negative: the pixels of the row y are shifted right by
+// int(float64(h-y)*slant) pixels, where h is the height and y is relative to
+// the top, so the top row moves the most. The image is widened by
+// int(float64(h)*slant) to keep all pixels. Pixels are moved without
+// resampling, so no stroke is lost. A slant of 0.2 is about 12 degrees.
+func (img *Image) Italicize(slant float64) *Image {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	o := img.rect.Min
+	dst := NewWithThreshold(image.Rect(o.X, o.Y, o.X+w+int(float64(h)*slant), o.Y+h), img.threshold)
+	for y := 0; y < h; y++ {
+		shift := int(float64(h-y) * slant)
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(x+shift, y, true)
+			}
+		}
+	}
+	return dst
+}
+
+// Rotate90 returns a new image rotated clockwise by 90 degrees, so the width
+// and the height are swapped.
+func (img *Image) Rotate90() *Image {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	o := img.rect.Min
+	dst := NewWithThreshold(image.Rect(o.X, o.Y, o.X+h, o.Y+w), img.threshold)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(h-1-y, x, true)
+			}
+		}
+	}
+	return dst
+}
+
+// Rotate180 returns a new image rotated by 180 degrees.
+func (img *Image) Rotate180() *Image {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	dst := NewWithThreshold(img.rect, img.threshold)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(w-1-x, h-1-y, true)
+			}
+		}
+	}
+	return dst
+}
+
+// Rotate270 returns a new image rotated counterclockwise by 90 degrees, so the
+// width and the height are swapped.
+func (img *Image) Rotate270() *Image {
+	w, h := img.rect.Dx(), img.rect.Dy()
+	o := img.rect.Min
+	dst := NewWithThreshold(image.Rect(o.X, o.Y, o.X+h, o.Y+w), img.threshold)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if img.bit(x, y) {
+				dst.setBit(y, w-1-x, true)
+			}
+		}
+	}
+	return dst
+}
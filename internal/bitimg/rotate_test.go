@@ -0,0 +1,126 @@
+package bitimg
+
+import (
+	"image"
+	"testing"
+)
+
+func TestRotate(t *testing.T) {
+	// An "L" of 3x4, which has no symmetry.
+	src := []string{
+		"100",
+		"100",
+		"100",
+		"111",
+	}
+	for _, tc := range []struct {
+		name   string
+		rotate func(*Image) *Image
+		want   []string
+	}{
+		{"90", (*Image).Rotate90, []string{
+			"1111",
+			"1000",
+			"1000",
+		}},
+		{"180", (*Image).Rotate180, []string{
+			"111",
+			"001",
+			"001",
+			"001",
+		}},
+		{"270", (*Image).Rotate270, []string{
+			"0001",
+			"0001",
+			"1111",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := parseImage(t, src...)
+			got := tc.rotate(img)
+			if got, want := got.String(), imageString(tc.want...); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+			if got.CountSetBits() != img.CountSetBits() {
+				t.Errorf("%d set pixels; want %d", got.CountSetBits(), img.CountSetBits())
+			}
+		})
+	}
+}
+
+func TestRotatePreservesSetBits(t *testing.T) {
+	// A width and a height not multiples of 8, so the rotations move the
+	// pixels across the padding bits.
+	img := New(image.Rect(2, 3, 15, 8))
+	img.DrawLine(2, 3, 14, 7, true)
+	img.DrawBorderRect(image.Rect(4, 3, 11, 8), true)
+	n := img.CountSetBits()
+	for _, rotated := range []*Image{
+		img.Rotate90(),
+		img.Rotate180(),
+		img.Rotate270(),
+		img.Rotate90().Rotate90().Rotate90().Rotate90(),
+	} {
+		if got := rotated.CountSetBits(); got != n {
+			t.Errorf("%v: %d set pixels; want %d", rotated.Bounds(), got, n)
+		}
+	}
+	if got := img.Rotate90().Rotate270(); !got.Equal(img) {
+		t.Errorf("Rotate90 and Rotate270:\n%s\nwant:\n%s", got, img)
+	}
+	if got := img.Rotate180().Rotate180(); !got.Equal(img) {
+		t.Errorf("Rotate180 twice:\n%s\nwant:\n%s", got, img)
+	}
+}
+
+func TestRotateArbitrary(t *testing.T) {
+	// An asymmetric image not at the origin, with a width not multiple of 8.
+	img := New(image.Rect(2, 3, 13, 8))
+	img.DrawLine(2, 3, 12, 6, true)
+	img.DrawRect(image.Rect(3, 6, 6, 8), true)
+	for _, bg := range []Bit{false, true} {
+		for _, tc := range []struct {
+			degrees float64
+			want    *Image
+		}{
+			{0, img},
+			{360, img},
+			{180, img.Rotate180()},
+			{-180, img.Rotate180()},
+			// Rotate270 is counterclockwise as RotateArbitrary.
+			{90, img.Rotate270()},
+			{270, img.Rotate90()},
+		} {
+			got := img.RotateArbitrary(tc.degrees, bg)
+			if got.Bounds() != tc.want.Bounds() {
+				t.Errorf("%v degrees, bg %v: bounds %v; want %v", tc.degrees, bg, got.Bounds(), tc.want.Bounds())
+				continue
+			}
+			if got.String() != tc.want.String() {
+				t.Errorf("%v degrees, bg %v:\n%s\nwant:\n%s", tc.degrees, bg, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestRotateArbitraryBackground(t *testing.T) {
+	img := New(image.Rect(0, 0, 4, 4))
+	img.DrawRect(img.Bounds(), true)
+	for _, bg := range []Bit{false, true} {
+		got := img.RotateArbitrary(45, bg)
+		if b := got.Bounds(); b.Dx() <= 4 || b.Dy() <= 4 {
+			t.Errorf("bounds %v don't contain the rotated square", b)
+		}
+		// The corners are outside the rotated source.
+		if c := got.Bounds().Min; got.BitAt(c.X, c.Y) != bg {
+			t.Errorf("corner of bg %v is %v", bg, got.BitAt(c.X, c.Y))
+		}
+		// The center is inside.
+		if c := got.Bounds().Size().Div(2).Add(got.Bounds().Min); !got.BitAt(c.X, c.Y) {
+			t.Errorf("center of bg %v is unset", bg)
+		}
+		if n, want := got.CountSetBits(), img.CountSetBits(); !bg && (n < want-4 || n > want+4) {
+			t.Errorf("%d set pixels; want about %d", n, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package bitimg
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// View is a writable view into a region of an Image, which shares the pixels
+// with the image.
+type View struct {
+	img  *Image
+	rect image.Rectangle
+}
+
+var _ draw.Image = (*View)(nil)
+
+// SubImage returns a view into the region r of the image. r is clipped to the
+// bounds of the image, so the view never writes outside of it. The view uses
+// the same coordinates as the image.
+func (img *Image) SubImage(r image.Rectangle) *View {
+	return &View{img: img, rect: r.Intersect(img.rect)}
+}
+
+func (v *View) ColorModel() color.Model {
+	return BitModel
+}
+
+func (v *View) Bounds() image.Rectangle {
+	return v.rect
+}
+
+func (v *View) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(v.rect) {
+		return color.Black
+	}
+	return v.img.At(x, y)
+}
+
+// BitAt returns the pixel at (x, y) as Bit. Pixels outside the view are
+// unset.
+func (v *View) BitAt(x, y int) Bit {
+	if !image.Pt(x, y).In(v.rect) {
+		return false
+	}
+	return v.img.BitAt(x, y)
+}
+
+func (v *View) Set(x, y int, c color.Color) {
+	if !image.Pt(x, y).In(v.rect) {
+		return
+	}
+	v.img.Set(x, y, c)
+}
+
+// SubImage returns a view into the region r of the view, clipped to the view.
+func (v *View) SubImage(r image.Rectangle) *View {
+	return &View{img: v.img, rect: r.Intersect(v.rect)}
+}
@@ -0,0 +1,54 @@
+package sfnttab
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// BitmapSizes returns the sizes in pixels per em of the strikes of embedded
+// bitmaps, in the EBLC, CBLC and sbix tables, sorted without duplicates.
+func (f *Font) BitmapSizes() ([]int, error) {
+	var sizes []int
+	for _, tag := range []string{"EBLC", "CBLC"} {
+		tab := f.Table(tag)
+		if tab == nil {
+			continue
+		}
+		if len(tab) < 8 {
+			return nil, fmt.Errorf("%w: %s table too short", ErrInvalid, tag)
+		}
+		// BitmapSize records are 48 bytes, and ppemY is at 45.
+		n := int(binary.BigEndian.Uint32(tab[4:]))
+		if len(tab) < 8+n*48 {
+			return nil, fmt.Errorf("%w: %s table too short", ErrInvalid, tag)
+		}
+		for i := 0; i < n; i++ {
+			sizes = append(sizes, int(tab[8+i*48+45]))
+		}
+	}
+	if tab := f.Table("sbix"); tab != nil {
+		if len(tab) < 8 {
+			return nil, fmt.Errorf("%w: sbix table too short", ErrInvalid)
+		}
+		n := int(binary.BigEndian.Uint32(tab[4:]))
+		if len(tab) < 8+n*4 {
+			return nil, fmt.Errorf("%w: sbix table too short", ErrInvalid)
+		}
+		for i := 0; i < n; i++ {
+			off := int(binary.BigEndian.Uint32(tab[8+i*4:]))
+			if off+2 > len(tab) {
+				return nil, fmt.Errorf("%w: sbix strike out of range", ErrInvalid)
+			}
+			sizes = append(sizes, int(binary.BigEndian.Uint16(tab[off:])))
+		}
+	}
+	slices.Sort(sizes)
+	return slices.Compact(sizes), nil
+}
+
+// HasOutlines reports whether the font has glyph outlines, in the glyf, CFF
+// or CFF2 table.
+func (f *Font) HasOutlines() bool {
+	return f.Table("glyf") != nil || f.Table("CFF ") != nil || f.Table("CFF2") != nil
+}
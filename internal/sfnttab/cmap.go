@@ -0,0 +1,118 @@
+package sfnttab
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Mapping is a mapping from a code point to a glyph index.
+type Mapping struct {
+	Rune  rune
+	Glyph uint16
+}
+
+// CMap returns the mappings of the best Unicode subtable of the cmap table.
+// Subtables of format 12 are preferred to format 4. The result is sorted by
+// code point.
+func (f *Font) CMap() ([]Mapping, error) {
+	cmap := f.Table("cmap")
+	if len(cmap) < 4 {
+		return nil, fmt.Errorf("%w: cmap table is missing", ErrInvalid)
+	}
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	if len(cmap) < 4+n*8 {
+		return nil, fmt.Errorf("%w: cmap table too short", ErrInvalid)
+	}
+	var fmt4, fmt12 []byte
+	for i := 0; i < n; i++ {
+		rec := cmap[4+i*8:]
+		platform := binary.BigEndian.Uint16(rec[0:])
+		encoding := binary.BigEndian.Uint16(rec[2:])
+		offset := binary.BigEndian.Uint32(rec[4:])
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		if uint64(offset)+2 > uint64(len(cmap)) {
+			continue
+		}
+		sub := cmap[offset:]
+		switch binary.BigEndian.Uint16(sub) {
+		case 4:
+			fmt4 = sub
+		case 12:
+			fmt12 = sub
+		}
+	}
+	switch {
+	case fmt12 != nil:
+		return parseCMapFormat12(fmt12)
+	case fmt4 != nil:
+		return parseCMapFormat4(fmt4)
+	}
+	return nil, fmt.Errorf("%w: no supported Unicode cmap subtable", ErrInvalid)
+}
+
+func parseCMapFormat4(b []byte) ([]Mapping, error) {
+	if len(b) < 14 {
+		return nil, fmt.Errorf("%w: cmap format 4 too short", ErrInvalid)
+	}
+	segX2 := int(binary.BigEndian.Uint16(b[6:]))
+	if len(b) < 16+segX2*4 {
+		return nil, fmt.Errorf("%w: cmap format 4 too short", ErrInvalid)
+	}
+	ends := b[14:]
+	starts := b[16+segX2:]
+	deltas := b[16+segX2*2:]
+	rangeOffsets := b[16+segX2*3:]
+	var m []Mapping
+	for i := 0; i < segX2; i += 2 {
+		end := binary.BigEndian.Uint16(ends[i:])
+		start := binary.BigEndian.Uint16(starts[i:])
+		delta := binary.BigEndian.Uint16(deltas[i:])
+		ro := int(binary.BigEndian.Uint16(rangeOffsets[i:]))
+		for c := uint32(start); c <= uint32(end) && c != 0xffff; c++ {
+			var gid uint16
+			if ro == 0 {
+				gid = uint16(c) + delta
+			} else {
+				// The offset is relative to the idRangeOffset entry itself.
+				idx := 16 + segX2*3 + i + ro + int(c-uint32(start))*2
+				if idx+2 > len(b) {
+					return nil, fmt.Errorf("%w: cmap format 4 out of range", ErrInvalid)
+				}
+				gid = binary.BigEndian.Uint16(b[idx:])
+				if gid != 0 {
+					gid += delta
+				}
+			}
+			if gid != 0 {
+				m = append(m, Mapping{Rune: rune(c), Glyph: gid})
+			}
+		}
+	}
+	return m, nil
+}
+
+func parseCMapFormat12(b []byte) ([]Mapping, error) {
+	if len(b) < 16 {
+		return nil, fmt.Errorf("%w: cmap format 12 too short", ErrInvalid)
+	}
+	n := int(binary.BigEndian.Uint32(b[12:]))
+	if n < 0 || len(b) < 16+n*12 {
+		return nil, fmt.Errorf("%w: cmap format 12 too short", ErrInvalid)
+	}
+	var m []Mapping
+	for i := 0; i < n; i++ {
+		g := b[16+i*12:]
+		start := binary.BigEndian.Uint32(g[0:])
+		end := binary.BigEndian.Uint32(g[4:])
+		gid := binary.BigEndian.Uint32(g[8:])
+		if end < start || end > 0x10ffff {
+			return nil, fmt.Errorf("%w: invalid cmap format 12 group", ErrInvalid)
+		}
+		for c := start; c <= end; c++ {
+			m = append(m, Mapping{Rune: rune(c), Glyph: uint16(gid + c - start)})
+		}
+	}
+	return m, nil
+}
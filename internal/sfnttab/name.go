@@ -0,0 +1,78 @@
+package sfnttab
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+)
+
+// Name is a decoded record of the name table.
+type Name struct {
+	PlatformID uint16
+	EncodingID uint16
+	LanguageID uint16
+	Value      string
+}
+
+// Names returns the records of the name table for the name ID, which can be
+// decoded: Unicode and Windows records in UTF-16BE, and Macintosh records in
+// ASCII.
+func (f *Font) Names(nameID uint16) ([]Name, error) {
+	tab := f.Table("name")
+	if len(tab) < 6 {
+		return nil, fmt.Errorf("%w: name table is missing", ErrInvalid)
+	}
+	n := int(binary.BigEndian.Uint16(tab[2:]))
+	storage := int(binary.BigEndian.Uint16(tab[4:]))
+	if len(tab) < 6+n*12 || storage > len(tab) {
+		return nil, fmt.Errorf("%w: name table too short", ErrInvalid)
+	}
+	var names []Name
+	for i := 0; i < n; i++ {
+		rec := tab[6+i*12:]
+		if binary.BigEndian.Uint16(rec[6:]) != nameID {
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(rec[8:]))
+		offset := storage + int(binary.BigEndian.Uint16(rec[10:]))
+		if offset+length > len(tab) {
+			continue
+		}
+		name := Name{
+			PlatformID: binary.BigEndian.Uint16(rec[0:]),
+			EncodingID: binary.BigEndian.Uint16(rec[2:]),
+			LanguageID: binary.BigEndian.Uint16(rec[4:]),
+		}
+		v, ok := decodeName(name.PlatformID, tab[offset:offset+length])
+		if !ok {
+			continue
+		}
+		name.Value = v
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func decodeName(platformID uint16, b []byte) (string, bool) {
+	switch platformID {
+	case 0, 3:
+		if len(b)%2 != 0 {
+			return "", false
+		}
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+		return string(utf16.Decode(u)), true
+	case 1:
+		// Mac Roman agrees with ASCII only.
+		for _, c := range b {
+			if c >= 0x80 {
+				return "", false
+			}
+		}
+		return string(b), true
+	default:
+		return "", false
+	}
+}
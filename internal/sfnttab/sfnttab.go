@@ -0,0 +1,96 @@
+// Package sfnttab provides raw access to the table directory of SFNT fonts.
+package sfnttab
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid is returned when the font data is not a valid SFNT font.
+var ErrInvalid = errors.New("sfnttab: invalid SFNT font data")
+
+// Table is an entry of the SFNT table directory.
+type Table struct {
+	Tag      string
+	Checksum uint32
+	Offset   uint32
+	Length   uint32
+}
+
+// Font is a parsed SFNT table directory with the font data.
+type Font struct {
+	data   []byte
+	Tables []Table
+}
+
+// Parse parses the table directory of the SFNT font data. TrueType
+// Collections are rejected: use ParseCollection for them.
+func Parse(data []byte) (*Font, error) {
+	return parseAt(data, 0)
+}
+
+// IsCollection reports whether data is a TrueType Collection.
+func IsCollection(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "ttcf"
+}
+
+// ParseCollection parses the table directory of the index-th font in the
+// TrueType Collection data.
+func ParseCollection(data []byte, index int) (*Font, error) {
+	if !IsCollection(data) || len(data) < 12 {
+		return nil, fmt.Errorf("%w: not a collection", ErrInvalid)
+	}
+	n := int(binary.BigEndian.Uint32(data[8:]))
+	if index < 0 || index >= n {
+		return nil, fmt.Errorf("font index %d out of range: the collection has %d fonts", index, n)
+	}
+	if len(data) < 12+n*4 {
+		return nil, ErrInvalid
+	}
+	return parseAt(data, int(binary.BigEndian.Uint32(data[12+index*4:])))
+}
+
+// parseAt parses the table directory at the offset off. Table offsets are
+// relative to the start of data in both single fonts and collections.
+func parseAt(data []byte, off int) (*Font, error) {
+	if off < 0 || len(data)-off < 12 {
+		return nil, ErrInvalid
+	}
+	dir := data[off:]
+	switch string(dir[:4]) {
+	case "\x00\x01\x00\x00", "OTTO", "true":
+	default:
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalid, dir[:4])
+	}
+	n := int(binary.BigEndian.Uint16(dir[4:]))
+	if len(dir) < 12+n*16 {
+		return nil, ErrInvalid
+	}
+	tables := make([]Table, n)
+	for i := range tables {
+		rec := dir[12+i*16:]
+		t := Table{
+			Tag:      string(rec[:4]),
+			Checksum: binary.BigEndian.Uint32(rec[4:]),
+			Offset:   binary.BigEndian.Uint32(rec[8:]),
+			Length:   binary.BigEndian.Uint32(rec[12:]),
+		}
+		if uint64(t.Offset)+uint64(t.Length) > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: table %q out of range", ErrInvalid, t.Tag)
+		}
+		tables[i] = t
+	}
+	return &Font{data: data, Tables: tables}, nil
+}
+
+// Table returns the raw bytes of the table with the tag, or nil when the font
+// doesn't have it.
+func (f *Font) Table(tag string) []byte {
+	for _, t := range f.Tables {
+		if t.Tag == tag {
+			return f.data[t.Offset : t.Offset+t.Length]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package sfnttab
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// subsetTables are the tables copied to subset fonts as they are. cmap, glyf,
+// loca and head are rebuilt.
+var subsetTables = []string{"OS/2", "hhea", "hmtx", "kern", "maxp", "name", "post", "vhea", "vmtx"}
+
+// Subset returns a font binary which contains only the glyphs of runes, and
+// the glyphs they refer as components. Glyph indices are kept, so metrics
+// tables are copied as they are, while the outlines of the other glyphs are
+// dropped. Only fonts with TrueType outlines are supported.
+func (f *Font) Subset(runes []rune) ([]byte, error) {
+	glyf, loca, head, maxp := f.Table("glyf"), f.Table("loca"), f.Table("head"), f.Table("maxp")
+	if glyf == nil || loca == nil {
+		return nil, fmt.Errorf("%w: subsetting supports only TrueType outlines", ErrInvalid)
+	}
+	if len(head) < 54 || len(maxp) < 6 {
+		return nil, fmt.Errorf("%w: head or maxp table is missing", ErrInvalid)
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	offsets, err := parseLoca(loca, numGlyphs, binary.BigEndian.Uint16(head[50:]) != 0, len(glyf))
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := f.CMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the mappings of runes, and the glyphs to keep.
+	want := map[rune]bool{}
+	for _, r := range runes {
+		want[r] = true
+	}
+	keep := map[uint16]bool{0: true}
+	var selected []Mapping
+	for _, m := range mappings {
+		if want[m.Rune] && int(m.Glyph) < numGlyphs {
+			selected = append(selected, m)
+			keep[m.Glyph] = true
+		}
+	}
+	glyphData := func(gid uint16) []byte {
+		return glyf[offsets[gid]:offsets[gid+1]]
+	}
+	// Add components of composite glyphs.
+	queue := make([]uint16, 0, len(keep))
+	for gid := range keep {
+		queue = append(queue, gid)
+	}
+	for len(queue) > 0 {
+		gid := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		for _, c := range components(glyphData(gid)) {
+			if int(c) < numGlyphs && !keep[c] {
+				keep[c] = true
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	// Rebuild glyf and loca with long offsets.
+	var newGlyf []byte
+	newLoca := make([]byte, (numGlyphs+1)*4)
+	for gid := 0; gid < numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(newLoca[gid*4:], uint32(len(newGlyf)))
+		if keep[uint16(gid)] {
+			newGlyf = append(newGlyf, glyphData(uint16(gid))...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[numGlyphs*4:], uint32(len(newGlyf)))
+
+	newHead := slices.Clone(head)
+	binary.BigEndian.PutUint16(newHead[50:], 1)
+	binary.BigEndian.PutUint32(newHead[8:], 0)
+
+	tables := map[string][]byte{
+		"cmap": buildCMapFormat12(selected),
+		"glyf": newGlyf,
+		"head": newHead,
+		"loca": newLoca,
+	}
+	for _, tag := range subsetTables {
+		if b := f.Table(tag); b != nil {
+			tables[tag] = b
+		}
+	}
+	return buildFont(tables), nil
+}
+
+// parseLoca returns glyph offsets of numGlyphs+1 entries, which must be in the
+// glyf table of glyfLen bytes.
+func parseLoca(loca []byte, numGlyphs int, long bool, glyfLen int) ([]uint32, error) {
+	offsets := make([]uint32, numGlyphs+1)
+	for i := range offsets {
+		if long {
+			if len(loca) < (i+1)*4 {
+				return nil, fmt.Errorf("%w: loca table too short", ErrInvalid)
+			}
+			offsets[i] = binary.BigEndian.Uint32(loca[i*4:])
+		} else {
+			if len(loca) < (i+1)*2 {
+				return nil, fmt.Errorf("%w: loca table too short", ErrInvalid)
+			}
+			offsets[i] = uint32(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return nil, fmt.Errorf("%w: loca table is not sorted", ErrInvalid)
+		}
+	}
+	if uint64(offsets[numGlyphs]) > uint64(glyfLen) {
+		return nil, fmt.Errorf("%w: loca table out of glyf table", ErrInvalid)
+	}
+	return offsets, nil
+}
+
+// components returns the glyph indices of the components of a composite
+// glyph. It returns nil for simple glyphs.
+func components(g []byte) []uint16 {
+	if len(g) < 10 || int16(binary.BigEndian.Uint16(g)) >= 0 {
+		return nil
+	}
+	const (
+		argsAreWords   = 0x0001
+		haveScale      = 0x0008
+		moreComponents = 0x0020
+		haveXYScale    = 0x0040
+		haveTwoByTwo   = 0x0080
+	)
+	var gids []uint16
+	for p := 10; p+4 <= len(g); {
+		flags := binary.BigEndian.Uint16(g[p:])
+		gids = append(gids, binary.BigEndian.Uint16(g[p+2:]))
+		p += 4
+		if flags&argsAreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+	return gids
+}
+
+// buildCMapFormat12 builds a cmap table with a (3, 10) subtable of format 12.
+// mappings must be sorted by code point.
+func buildCMapFormat12(mappings []Mapping) []byte {
+	type group struct{ start, end, gid uint32 }
+	var groups []group
+	for _, m := range mappings {
+		if n := len(groups); n > 0 {
+			g := &groups[n-1]
+			if uint32(m.Rune) == g.end+1 && uint32(m.Glyph) == g.gid+g.end+1-g.start {
+				g.end++
+				continue
+			}
+		}
+		groups = append(groups, group{uint32(m.Rune), uint32(m.Rune), uint32(m.Glyph)})
+	}
+	b := make([]byte, 12+16+len(groups)*12)
+	// cmap header with one encoding record.
+	binary.BigEndian.PutUint16(b[2:], 1)
+	binary.BigEndian.PutUint16(b[4:], 3)
+	binary.BigEndian.PutUint16(b[6:], 10)
+	binary.BigEndian.PutUint32(b[8:], 12)
+	// Subtable of format 12.
+	sub := b[12:]
+	binary.BigEndian.PutUint16(sub[0:], 12)
+	binary.BigEndian.PutUint32(sub[4:], uint32(len(sub)))
+	binary.BigEndian.PutUint32(sub[12:], uint32(len(groups)))
+	for i, g := range groups {
+		binary.BigEndian.PutUint32(sub[16+i*12:], g.start)
+		binary.BigEndian.PutUint32(sub[20+i*12:], g.end)
+		binary.BigEndian.PutUint32(sub[24+i*12:], g.gid)
+	}
+	return b
+}
+
+func checksum(b []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(b); i += 4 {
+		var v [4]byte
+		copy(v[:], b[i:])
+		sum += binary.BigEndian.Uint32(v[:])
+	}
+	return sum
+}
+
+// buildFont builds a font binary of TrueType outlines from the tables.
+func buildFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	n := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+	out := make([]byte, 12+n*16)
+	binary.BigEndian.PutUint32(out[0:], 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(n))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(n*16-searchRange))
+
+	// Lay out the tables on 4-byte boundaries.
+	offsets := make([]int, n)
+	size := len(out)
+	for i, tag := range tags {
+		offsets[i] = size
+		size += (len(tables[tag]) + 3) &^ 3
+	}
+	out = append(out, make([]byte, size-len(out))...)
+
+	headOffset := -1
+	for i, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			headOffset = offsets[i]
+		}
+		rec := out[12+i*16:]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], checksum(data))
+		binary.BigEndian.PutUint32(rec[8:], uint32(offsets[i]))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		copy(out[offsets[i]:], data)
+	}
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(out[headOffset+8:], 0xB1B0AFBA-checksum(out))
+	}
+	return out
+}
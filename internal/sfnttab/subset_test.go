@@ -0,0 +1,40 @@
+package sfnttab
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseLoca(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		loca    []byte
+		long    bool
+		glyfLen int
+		want    []uint32
+		wantErr bool
+	}{
+		{"short", []byte{0, 0, 0, 2, 0, 5}, false, 10, []uint32{0, 4, 10}, false},
+		{"long", []byte{0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 10}, true, 10, []uint32{0, 4, 10}, false},
+		{"truncated", []byte{0, 0, 0, 2}, false, 10, nil, true},
+		{"unsorted", []byte{0, 0, 0, 5, 0, 2}, false, 10, nil, true},
+		{"beyond glyf", []byte{0, 0, 0, 2, 0, 6}, false, 10, nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseLoca(tc.loca, 2, tc.long, tc.glyfLen)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("got %v, %v; want ErrInvalid", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
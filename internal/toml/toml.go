@@ -0,0 +1,324 @@
+// Package toml parses the subset of TOML used by the config files: key/value
+// pairs of strings, integers, floats, booleans and arrays of them, tables,
+// and arrays of tables. Dotted keys, inline tables, multi-line strings and
+// date-times are not supported.
+package toml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table is a TOML table. Its values are string, int64, float64, bool, []any,
+// Table or []Table.
+type Table map[string]any
+
+// SyntaxError is an error of Parse, with the line where it is found.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("toml: line %d: %s", e.Line, e.Msg)
+}
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+// Parse parses a TOML document.
+func Parse(data []byte) (Table, error) {
+	p := &parser{src: string(data), line: 1}
+	root := Table{}
+	cur := root
+	for {
+		p.skipSpaceAndNewlines()
+		if p.eof() {
+			return root, nil
+		}
+		switch {
+		case strings.HasPrefix(p.rest(), "[["):
+			p.pos += 2
+			name, err := p.header("]]")
+			if err != nil {
+				return nil, err
+			}
+			var arr []Table
+			switch v := root[name].(type) {
+			case nil:
+			case []Table:
+				arr = v
+			default:
+				return nil, p.errorf("%q is already defined", name)
+			}
+			cur = Table{}
+			root[name] = append(arr, cur)
+		case p.peek() == '[':
+			p.pos++
+			name, err := p.header("]")
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := root[name]; ok {
+				return nil, p.errorf("%q is already defined", name)
+			}
+			cur = Table{}
+			root[name] = cur
+		default:
+			if err := p.keyValue(cur); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// header parses the name of a table header, and its closing brackets.
+func (p *parser) header(closing string) (string, error) {
+	p.skipSpace()
+	name, err := p.key()
+	if err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	if !strings.HasPrefix(p.rest(), closing) {
+		return "", p.errorf("expected %q", closing)
+	}
+	p.pos += len(closing)
+	return name, p.endOfLine()
+}
+
+// keyValue parses a "key = value" line into t.
+func (p *parser) keyValue(t Table) error {
+	k, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after %q", k)
+	}
+	p.pos++
+	p.skipSpace()
+	v, err := p.value()
+	if err != nil {
+		return err
+	}
+	if _, ok := t[k]; ok {
+		return p.errorf("%q is already defined", k)
+	}
+	t[k] = v
+	return p.endOfLine()
+}
+
+// key parses a bare or quoted key.
+func (p *parser) key() (string, error) {
+	switch p.peek() {
+	case '"':
+		return p.basicString()
+	case '\'':
+		return p.literalString()
+	}
+	start := p.pos
+	for !p.eof() && isBareKeyChar(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a key")
+	}
+	if p.peek() == '.' {
+		return "", p.errorf("dotted keys are not supported")
+	}
+	return p.src[start:p.pos], nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value parses a value.
+func (p *parser) value() (any, error) {
+	switch c := p.peek(); {
+	case c == '"':
+		if strings.HasPrefix(p.rest(), `"""`) {
+			return nil, p.errorf("multi-line strings are not supported")
+		}
+		return p.basicString()
+	case c == '\'':
+		return p.literalString()
+	case c == '[':
+		return p.array()
+	case c == '{':
+		return nil, p.errorf("inline tables are not supported")
+	}
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n#,]", rune(p.peek())) {
+		p.pos++
+	}
+	s := p.src[start:p.pos]
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "":
+		return nil, p.errorf("expected a value")
+	}
+	num := strings.ReplaceAll(s, "_", "")
+	if n, err := strconv.ParseInt(num, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil {
+		return f, nil
+	}
+	return nil, p.errorf("invalid value %q", s)
+}
+
+// array parses an array, which may span multiple lines.
+func (p *parser) array() ([]any, error) {
+	p.pos++ // '['
+	arr := []any{}
+	for {
+		p.skipSpaceAndNewlines()
+		if p.peek() == ']' {
+			p.pos++
+			return arr, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+		p.skipSpaceAndNewlines()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in an array")
+		}
+	}
+}
+
+// basicString parses a string in double quotes, with escape sequences.
+func (p *parser) basicString() (string, error) {
+	p.pos++ // '"'
+	b := &strings.Builder{}
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string")
+			}
+			e := p.src[p.pos]
+			p.pos++
+			switch e {
+			case 'b':
+				b.WriteByte('\b')
+			case 't':
+				b.WriteByte('\t')
+			case 'n':
+				b.WriteByte('\n')
+			case 'f':
+				b.WriteByte('\f')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(e)
+			case 'u', 'U':
+				n := 4
+				if e == 'U' {
+					n = 8
+				}
+				if p.pos+n > len(p.src) {
+					return "", p.errorf("invalid escape sequence")
+				}
+				r, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(r)) {
+					return "", p.errorf("invalid escape sequence")
+				}
+				p.pos += n
+				b.WriteRune(rune(r))
+			default:
+				return "", p.errorf("invalid escape sequence \\%c", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// literalString parses a string in single quotes, without escapes.
+func (p *parser) literalString() (string, error) {
+	p.pos++ // '\''
+	end := strings.IndexAny(p.rest(), "'\n")
+	if end < 0 || p.rest()[end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.rest()[:end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// endOfLine skips a comment, and checks there is nothing else on the line.
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	if p.eof() || p.peek() == '\n' {
+		return nil
+	}
+	return p.errorf("unexpected %q", p.peek())
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) rest() string { return p.src[p.pos:] }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipSpace skips spaces, tabs and a comment, but not newlines.
+func (p *parser) skipSpace() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skipSpaceAndNewlines skips spaces, comments and newlines.
+func (p *parser) skipSpaceAndNewlines() {
+	for {
+		p.skipSpace()
+		if p.peek() != '\n' {
+			return
+		}
+		p.pos++
+		p.line++
+	}
+}
+
+func (p *parser) errorf(format string, a ...any) error {
+	return &SyntaxError{Line: p.line, Msg: fmt.Sprintf(format, a...)}
+}
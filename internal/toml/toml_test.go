@@ -0,0 +1,87 @@
+package toml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		src  string
+		want Table
+	}{
+		{"empty", "", Table{}},
+		{"values", "s = \"a\"\ni = 1_000\nh = 0x10\nf = 0.5\nb = true\nn = -3\n",
+			Table{"s": "a", "i": int64(1000), "h": int64(16), "f": 0.5, "b": true, "n": int64(-3)}},
+		{"escapes", `s = "tab\there \"q\" \\ \u00e9\U0001F600\n"` + "\n",
+			Table{"s": "tab\there \"q\" \\ \u00e9\U0001F600\n"}},
+		{"literal string", `s = 'C:\fonts\"x"'` + "\n", Table{"s": `C:\fonts\"x"`}},
+		{"quoted keys", `"a b" = 1` + "\n'c' = 2\n", Table{"a b": int64(1), "c": int64(2)}},
+		{"comments", "# head\na = 1 # tail\n  # indented\nb = \"#not a comment\"\n",
+			Table{"a": int64(1), "b": "#not a comment"}},
+		{"arrays", "a = [1, 2, 3]\nb = []\nc = [\n  \"x\", # comment\n  'y',\n]\nd = [[1], [true, 0.5]]\n",
+			Table{
+				"a": []any{int64(1), int64(2), int64(3)},
+				"b": []any{},
+				"c": []any{"x", "y"},
+				"d": []any{[]any{int64(1)}, []any{true, 0.5}},
+			}},
+		{"tables", "size = 16\n[font]\nname = \"Go\"\n[out]\ngz = false\n",
+			Table{"size": int64(16), "font": Table{"name": "Go"}, "out": Table{"gz": false}}},
+		{"array of tables", "[[inputs]]\nfont = \"a.ttf\"\n[[inputs]]\nfont = \"b.ttf\"\nsize = 12\n",
+			Table{"inputs": []Table{{"font": "a.ttf"}, {"font": "b.ttf", "size": int64(12)}}}},
+		{"CRLF", "a = 1\r\nb = 'x'\r\n", Table{"a": int64(1), "b": "x"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse([]byte(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %#v; want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		src  string
+		line int
+	}{
+		{"missing equals", "a 1\n", 1},
+		{"missing value", "a =\n", 1},
+		{"invalid value", "a = yes\n", 1},
+		{"trailing garbage", "a = 1 2\n", 1},
+		{"unterminated string", "a = 1\nb = \"x\n", 2},
+		{"unterminated literal string", "a = 'x\n", 1},
+		{"invalid escape", `a = "\q"` + "\n", 1},
+		{"invalid unicode escape", `a = "\uD800"` + "\n", 1},
+		{"unclosed array", "a = [1,\n2\n", 3},
+		{"unclosed header", "\n\n[font\n", 3},
+		{"dotted key", "# comment\na.b = 1\n", 2},
+		{"dotted header", "[a.b]\n", 1},
+		{"inline table", "a = 1\n\nb = {c = 1}\n", 3},
+		{"multi-line string", "a = \"\"\"\nx\n\"\"\"\n", 1},
+		{"duplicate key", "a = 1\nb = 2\na = 3\n", 3},
+		{"duplicate key in table", "a = 1\n[t]\na = 2\na = 3\n", 4},
+		{"duplicate table after others", "[t]\na = 1\n[u]\n[[v]]\n[t]\n", 5},
+		{"duplicate table", "[t]\n[t]\n", 2},
+		{"table and array of tables", "[t]\n[[t]]\n", 2},
+		{"key and table", "t = 1\n[t]\n", 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.src))
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("got %v; want a SyntaxError", err)
+			}
+			if se.Line != tc.line {
+				t.Errorf("%v: at line %d; want %d", se, se.Line, tc.line)
+			}
+		})
+	}
+}
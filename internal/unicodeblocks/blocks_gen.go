@@ -0,0 +1,328 @@
+// Code generated by gen/genblocks.go. DO NOT EDIT.
+// Source: Blocks-14.0.0.txt
+
+package unicodeblocks
+
+// Blocks is the table of Unicode blocks, sorted by Start.
+var Blocks = []Block{
+	{Start: 0x0000, End: 0x007F, Name: "Basic Latin"},
+	{Start: 0x0080, End: 0x00FF, Name: "Latin-1 Supplement"},
+	{Start: 0x0100, End: 0x017F, Name: "Latin Extended-A"},
+	{Start: 0x0180, End: 0x024F, Name: "Latin Extended-B"},
+	{Start: 0x0250, End: 0x02AF, Name: "IPA Extensions"},
+	{Start: 0x02B0, End: 0x02FF, Name: "Spacing Modifier Letters"},
+	{Start: 0x0300, End: 0x036F, Name: "Combining Diacritical Marks"},
+	{Start: 0x0370, End: 0x03FF, Name: "Greek and Coptic"},
+	{Start: 0x0400, End: 0x04FF, Name: "Cyrillic"},
+	{Start: 0x0500, End: 0x052F, Name: "Cyrillic Supplement"},
+	{Start: 0x0530, End: 0x058F, Name: "Armenian"},
+	{Start: 0x0590, End: 0x05FF, Name: "Hebrew"},
+	{Start: 0x0600, End: 0x06FF, Name: "Arabic"},
+	{Start: 0x0700, End: 0x074F, Name: "Syriac"},
+	{Start: 0x0750, End: 0x077F, Name: "Arabic Supplement"},
+	{Start: 0x0780, End: 0x07BF, Name: "Thaana"},
+	{Start: 0x07C0, End: 0x07FF, Name: "NKo"},
+	{Start: 0x0800, End: 0x083F, Name: "Samaritan"},
+	{Start: 0x0840, End: 0x085F, Name: "Mandaic"},
+	{Start: 0x0860, End: 0x086F, Name: "Syriac Supplement"},
+	{Start: 0x0870, End: 0x089F, Name: "Arabic Extended-B"},
+	{Start: 0x08A0, End: 0x08FF, Name: "Arabic Extended-A"},
+	{Start: 0x0900, End: 0x097F, Name: "Devanagari"},
+	{Start: 0x0980, End: 0x09FF, Name: "Bengali"},
+	{Start: 0x0A00, End: 0x0A7F, Name: "Gurmukhi"},
+	{Start: 0x0A80, End: 0x0AFF, Name: "Gujarati"},
+	{Start: 0x0B00, End: 0x0B7F, Name: "Oriya"},
+	{Start: 0x0B80, End: 0x0BFF, Name: "Tamil"},
+	{Start: 0x0C00, End: 0x0C7F, Name: "Telugu"},
+	{Start: 0x0C80, End: 0x0CFF, Name: "Kannada"},
+	{Start: 0x0D00, End: 0x0D7F, Name: "Malayalam"},
+	{Start: 0x0D80, End: 0x0DFF, Name: "Sinhala"},
+	{Start: 0x0E00, End: 0x0E7F, Name: "Thai"},
+	{Start: 0x0E80, End: 0x0EFF, Name: "Lao"},
+	{Start: 0x0F00, End: 0x0FFF, Name: "Tibetan"},
+	{Start: 0x1000, End: 0x109F, Name: "Myanmar"},
+	{Start: 0x10A0, End: 0x10FF, Name: "Georgian"},
+	{Start: 0x1100, End: 0x11FF, Name: "Hangul Jamo"},
+	{Start: 0x1200, End: 0x137F, Name: "Ethiopic"},
+	{Start: 0x1380, End: 0x139F, Name: "Ethiopic Supplement"},
+	{Start: 0x13A0, End: 0x13FF, Name: "Cherokee"},
+	{Start: 0x1400, End: 0x167F, Name: "Unified Canadian Aboriginal Syllabics"},
+	{Start: 0x1680, End: 0x169F, Name: "Ogham"},
+	{Start: 0x16A0, End: 0x16FF, Name: "Runic"},
+	{Start: 0x1700, End: 0x171F, Name: "Tagalog"},
+	{Start: 0x1720, End: 0x173F, Name: "Hanunoo"},
+	{Start: 0x1740, End: 0x175F, Name: "Buhid"},
+	{Start: 0x1760, End: 0x177F, Name: "Tagbanwa"},
+	{Start: 0x1780, End: 0x17FF, Name: "Khmer"},
+	{Start: 0x1800, End: 0x18AF, Name: "Mongolian"},
+	{Start: 0x18B0, End: 0x18FF, Name: "Unified Canadian Aboriginal Syllabics Extended"},
+	{Start: 0x1900, End: 0x194F, Name: "Limbu"},
+	{Start: 0x1950, End: 0x197F, Name: "Tai Le"},
+	{Start: 0x1980, End: 0x19DF, Name: "New Tai Lue"},
+	{Start: 0x19E0, End: 0x19FF, Name: "Khmer Symbols"},
+	{Start: 0x1A00, End: 0x1A1F, Name: "Buginese"},
+	{Start: 0x1A20, End: 0x1AAF, Name: "Tai Tham"},
+	{Start: 0x1AB0, End: 0x1AFF, Name: "Combining Diacritical Marks Extended"},
+	{Start: 0x1B00, End: 0x1B7F, Name: "Balinese"},
+	{Start: 0x1B80, End: 0x1BBF, Name: "Sundanese"},
+	{Start: 0x1BC0, End: 0x1BFF, Name: "Batak"},
+	{Start: 0x1C00, End: 0x1C4F, Name: "Lepcha"},
+	{Start: 0x1C50, End: 0x1C7F, Name: "Ol Chiki"},
+	{Start: 0x1C80, End: 0x1C8F, Name: "Cyrillic Extended-C"},
+	{Start: 0x1C90, End: 0x1CBF, Name: "Georgian Extended"},
+	{Start: 0x1CC0, End: 0x1CCF, Name: "Sundanese Supplement"},
+	{Start: 0x1CD0, End: 0x1CFF, Name: "Vedic Extensions"},
+	{Start: 0x1D00, End: 0x1D7F, Name: "Phonetic Extensions"},
+	{Start: 0x1D80, End: 0x1DBF, Name: "Phonetic Extensions Supplement"},
+	{Start: 0x1DC0, End: 0x1DFF, Name: "Combining Diacritical Marks Supplement"},
+	{Start: 0x1E00, End: 0x1EFF, Name: "Latin Extended Additional"},
+	{Start: 0x1F00, End: 0x1FFF, Name: "Greek Extended"},
+	{Start: 0x2000, End: 0x206F, Name: "General Punctuation"},
+	{Start: 0x2070, End: 0x209F, Name: "Superscripts and Subscripts"},
+	{Start: 0x20A0, End: 0x20CF, Name: "Currency Symbols"},
+	{Start: 0x20D0, End: 0x20FF, Name: "Combining Diacritical Marks for Symbols"},
+	{Start: 0x2100, End: 0x214F, Name: "Letterlike Symbols"},
+	{Start: 0x2150, End: 0x218F, Name: "Number Forms"},
+	{Start: 0x2190, End: 0x21FF, Name: "Arrows"},
+	{Start: 0x2200, End: 0x22FF, Name: "Mathematical Operators"},
+	{Start: 0x2300, End: 0x23FF, Name: "Miscellaneous Technical"},
+	{Start: 0x2400, End: 0x243F, Name: "Control Pictures"},
+	{Start: 0x2440, End: 0x245F, Name: "Optical Character Recognition"},
+	{Start: 0x2460, End: 0x24FF, Name: "Enclosed Alphanumerics"},
+	{Start: 0x2500, End: 0x257F, Name: "Box Drawing"},
+	{Start: 0x2580, End: 0x259F, Name: "Block Elements"},
+	{Start: 0x25A0, End: 0x25FF, Name: "Geometric Shapes"},
+	{Start: 0x2600, End: 0x26FF, Name: "Miscellaneous Symbols"},
+	{Start: 0x2700, End: 0x27BF, Name: "Dingbats"},
+	{Start: 0x27C0, End: 0x27EF, Name: "Miscellaneous Mathematical Symbols-A"},
+	{Start: 0x27F0, End: 0x27FF, Name: "Supplemental Arrows-A"},
+	{Start: 0x2800, End: 0x28FF, Name: "Braille Patterns"},
+	{Start: 0x2900, End: 0x297F, Name: "Supplemental Arrows-B"},
+	{Start: 0x2980, End: 0x29FF, Name: "Miscellaneous Mathematical Symbols-B"},
+	{Start: 0x2A00, End: 0x2AFF, Name: "Supplemental Mathematical Operators"},
+	{Start: 0x2B00, End: 0x2BFF, Name: "Miscellaneous Symbols and Arrows"},
+	{Start: 0x2C00, End: 0x2C5F, Name: "Glagolitic"},
+	{Start: 0x2C60, End: 0x2C7F, Name: "Latin Extended-C"},
+	{Start: 0x2C80, End: 0x2CFF, Name: "Coptic"},
+	{Start: 0x2D00, End: 0x2D2F, Name: "Georgian Supplement"},
+	{Start: 0x2D30, End: 0x2D7F, Name: "Tifinagh"},
+	{Start: 0x2D80, End: 0x2DDF, Name: "Ethiopic Extended"},
+	{Start: 0x2DE0, End: 0x2DFF, Name: "Cyrillic Extended-A"},
+	{Start: 0x2E00, End: 0x2E7F, Name: "Supplemental Punctuation"},
+	{Start: 0x2E80, End: 0x2EFF, Name: "CJK Radicals Supplement"},
+	{Start: 0x2F00, End: 0x2FDF, Name: "Kangxi Radicals"},
+	{Start: 0x2FF0, End: 0x2FFF, Name: "Ideographic Description Characters"},
+	{Start: 0x3000, End: 0x303F, Name: "CJK Symbols and Punctuation"},
+	{Start: 0x3040, End: 0x309F, Name: "Hiragana"},
+	{Start: 0x30A0, End: 0x30FF, Name: "Katakana"},
+	{Start: 0x3100, End: 0x312F, Name: "Bopomofo"},
+	{Start: 0x3130, End: 0x318F, Name: "Hangul Compatibility Jamo"},
+	{Start: 0x3190, End: 0x319F, Name: "Kanbun"},
+	{Start: 0x31A0, End: 0x31BF, Name: "Bopomofo Extended"},
+	{Start: 0x31C0, End: 0x31EF, Name: "CJK Strokes"},
+	{Start: 0x31F0, End: 0x31FF, Name: "Katakana Phonetic Extensions"},
+	{Start: 0x3200, End: 0x32FF, Name: "Enclosed CJK Letters and Months"},
+	{Start: 0x3300, End: 0x33FF, Name: "CJK Compatibility"},
+	{Start: 0x3400, End: 0x4DBF, Name: "CJK Unified Ideographs Extension A"},
+	{Start: 0x4DC0, End: 0x4DFF, Name: "Yijing Hexagram Symbols"},
+	{Start: 0x4E00, End: 0x9FFF, Name: "CJK Unified Ideographs"},
+	{Start: 0xA000, End: 0xA48F, Name: "Yi Syllables"},
+	{Start: 0xA490, End: 0xA4CF, Name: "Yi Radicals"},
+	{Start: 0xA4D0, End: 0xA4FF, Name: "Lisu"},
+	{Start: 0xA500, End: 0xA63F, Name: "Vai"},
+	{Start: 0xA640, End: 0xA69F, Name: "Cyrillic Extended-B"},
+	{Start: 0xA6A0, End: 0xA6FF, Name: "Bamum"},
+	{Start: 0xA700, End: 0xA71F, Name: "Modifier Tone Letters"},
+	{Start: 0xA720, End: 0xA7FF, Name: "Latin Extended-D"},
+	{Start: 0xA800, End: 0xA82F, Name: "Syloti Nagri"},
+	{Start: 0xA830, End: 0xA83F, Name: "Common Indic Number Forms"},
+	{Start: 0xA840, End: 0xA87F, Name: "Phags-pa"},
+	{Start: 0xA880, End: 0xA8DF, Name: "Saurashtra"},
+	{Start: 0xA8E0, End: 0xA8FF, Name: "Devanagari Extended"},
+	{Start: 0xA900, End: 0xA92F, Name: "Kayah Li"},
+	{Start: 0xA930, End: 0xA95F, Name: "Rejang"},
+	{Start: 0xA960, End: 0xA97F, Name: "Hangul Jamo Extended-A"},
+	{Start: 0xA980, End: 0xA9DF, Name: "Javanese"},
+	{Start: 0xA9E0, End: 0xA9FF, Name: "Myanmar Extended-B"},
+	{Start: 0xAA00, End: 0xAA5F, Name: "Cham"},
+	{Start: 0xAA60, End: 0xAA7F, Name: "Myanmar Extended-A"},
+	{Start: 0xAA80, End: 0xAADF, Name: "Tai Viet"},
+	{Start: 0xAAE0, End: 0xAAFF, Name: "Meetei Mayek Extensions"},
+	{Start: 0xAB00, End: 0xAB2F, Name: "Ethiopic Extended-A"},
+	{Start: 0xAB30, End: 0xAB6F, Name: "Latin Extended-E"},
+	{Start: 0xAB70, End: 0xABBF, Name: "Cherokee Supplement"},
+	{Start: 0xABC0, End: 0xABFF, Name: "Meetei Mayek"},
+	{Start: 0xAC00, End: 0xD7AF, Name: "Hangul Syllables"},
+	{Start: 0xD7B0, End: 0xD7FF, Name: "Hangul Jamo Extended-B"},
+	{Start: 0xD800, End: 0xDB7F, Name: "High Surrogates"},
+	{Start: 0xDB80, End: 0xDBFF, Name: "High Private Use Surrogates"},
+	{Start: 0xDC00, End: 0xDFFF, Name: "Low Surrogates"},
+	{Start: 0xE000, End: 0xF8FF, Name: "Private Use Area"},
+	{Start: 0xF900, End: 0xFAFF, Name: "CJK Compatibility Ideographs"},
+	{Start: 0xFB00, End: 0xFB4F, Name: "Alphabetic Presentation Forms"},
+	{Start: 0xFB50, End: 0xFDFF, Name: "Arabic Presentation Forms-A"},
+	{Start: 0xFE00, End: 0xFE0F, Name: "Variation Selectors"},
+	{Start: 0xFE10, End: 0xFE1F, Name: "Vertical Forms"},
+	{Start: 0xFE20, End: 0xFE2F, Name: "Combining Half Marks"},
+	{Start: 0xFE30, End: 0xFE4F, Name: "CJK Compatibility Forms"},
+	{Start: 0xFE50, End: 0xFE6F, Name: "Small Form Variants"},
+	{Start: 0xFE70, End: 0xFEFF, Name: "Arabic Presentation Forms-B"},
+	{Start: 0xFF00, End: 0xFFEF, Name: "Halfwidth and Fullwidth Forms"},
+	{Start: 0xFFF0, End: 0xFFFF, Name: "Specials"},
+	{Start: 0x10000, End: 0x1007F, Name: "Linear B Syllabary"},
+	{Start: 0x10080, End: 0x100FF, Name: "Linear B Ideograms"},
+	{Start: 0x10100, End: 0x1013F, Name: "Aegean Numbers"},
+	{Start: 0x10140, End: 0x1018F, Name: "Ancient Greek Numbers"},
+	{Start: 0x10190, End: 0x101CF, Name: "Ancient Symbols"},
+	{Start: 0x101D0, End: 0x101FF, Name: "Phaistos Disc"},
+	{Start: 0x10280, End: 0x1029F, Name: "Lycian"},
+	{Start: 0x102A0, End: 0x102DF, Name: "Carian"},
+	{Start: 0x102E0, End: 0x102FF, Name: "Coptic Epact Numbers"},
+	{Start: 0x10300, End: 0x1032F, Name: "Old Italic"},
+	{Start: 0x10330, End: 0x1034F, Name: "Gothic"},
+	{Start: 0x10350, End: 0x1037F, Name: "Old Permic"},
+	{Start: 0x10380, End: 0x1039F, Name: "Ugaritic"},
+	{Start: 0x103A0, End: 0x103DF, Name: "Old Persian"},
+	{Start: 0x10400, End: 0x1044F, Name: "Deseret"},
+	{Start: 0x10450, End: 0x1047F, Name: "Shavian"},
+	{Start: 0x10480, End: 0x104AF, Name: "Osmanya"},
+	{Start: 0x104B0, End: 0x104FF, Name: "Osage"},
+	{Start: 0x10500, End: 0x1052F, Name: "Elbasan"},
+	{Start: 0x10530, End: 0x1056F, Name: "Caucasian Albanian"},
+	{Start: 0x10570, End: 0x105BF, Name: "Vithkuqi"},
+	{Start: 0x10600, End: 0x1077F, Name: "Linear A"},
+	{Start: 0x10780, End: 0x107BF, Name: "Latin Extended-F"},
+	{Start: 0x10800, End: 0x1083F, Name: "Cypriot Syllabary"},
+	{Start: 0x10840, End: 0x1085F, Name: "Imperial Aramaic"},
+	{Start: 0x10860, End: 0x1087F, Name: "Palmyrene"},
+	{Start: 0x10880, End: 0x108AF, Name: "Nabataean"},
+	{Start: 0x108E0, End: 0x108FF, Name: "Hatran"},
+	{Start: 0x10900, End: 0x1091F, Name: "Phoenician"},
+	{Start: 0x10920, End: 0x1093F, Name: "Lydian"},
+	{Start: 0x10980, End: 0x1099F, Name: "Meroitic Hieroglyphs"},
+	{Start: 0x109A0, End: 0x109FF, Name: "Meroitic Cursive"},
+	{Start: 0x10A00, End: 0x10A5F, Name: "Kharoshthi"},
+	{Start: 0x10A60, End: 0x10A7F, Name: "Old South Arabian"},
+	{Start: 0x10A80, End: 0x10A9F, Name: "Old North Arabian"},
+	{Start: 0x10AC0, End: 0x10AFF, Name: "Manichaean"},
+	{Start: 0x10B00, End: 0x10B3F, Name: "Avestan"},
+	{Start: 0x10B40, End: 0x10B5F, Name: "Inscriptional Parthian"},
+	{Start: 0x10B60, End: 0x10B7F, Name: "Inscriptional Pahlavi"},
+	{Start: 0x10B80, End: 0x10BAF, Name: "Psalter Pahlavi"},
+	{Start: 0x10C00, End: 0x10C4F, Name: "Old Turkic"},
+	{Start: 0x10C80, End: 0x10CFF, Name: "Old Hungarian"},
+	{Start: 0x10D00, End: 0x10D3F, Name: "Hanifi Rohingya"},
+	{Start: 0x10E60, End: 0x10E7F, Name: "Rumi Numeral Symbols"},
+	{Start: 0x10E80, End: 0x10EBF, Name: "Yezidi"},
+	{Start: 0x10F00, End: 0x10F2F, Name: "Old Sogdian"},
+	{Start: 0x10F30, End: 0x10F6F, Name: "Sogdian"},
+	{Start: 0x10F70, End: 0x10FAF, Name: "Old Uyghur"},
+	{Start: 0x10FB0, End: 0x10FDF, Name: "Chorasmian"},
+	{Start: 0x10FE0, End: 0x10FFF, Name: "Elymaic"},
+	{Start: 0x11000, End: 0x1107F, Name: "Brahmi"},
+	{Start: 0x11080, End: 0x110CF, Name: "Kaithi"},
+	{Start: 0x110D0, End: 0x110FF, Name: "Sora Sompeng"},
+	{Start: 0x11100, End: 0x1114F, Name: "Chakma"},
+	{Start: 0x11150, End: 0x1117F, Name: "Mahajani"},
+	{Start: 0x11180, End: 0x111DF, Name: "Sharada"},
+	{Start: 0x111E0, End: 0x111FF, Name: "Sinhala Archaic Numbers"},
+	{Start: 0x11200, End: 0x1124F, Name: "Khojki"},
+	{Start: 0x11280, End: 0x112AF, Name: "Multani"},
+	{Start: 0x112B0, End: 0x112FF, Name: "Khudawadi"},
+	{Start: 0x11300, End: 0x1137F, Name: "Grantha"},
+	{Start: 0x11400, End: 0x1147F, Name: "Newa"},
+	{Start: 0x11480, End: 0x114DF, Name: "Tirhuta"},
+	{Start: 0x11580, End: 0x115FF, Name: "Siddham"},
+	{Start: 0x11600, End: 0x1165F, Name: "Modi"},
+	{Start: 0x11660, End: 0x1167F, Name: "Mongolian Supplement"},
+	{Start: 0x11680, End: 0x116CF, Name: "Takri"},
+	{Start: 0x11700, End: 0x1174F, Name: "Ahom"},
+	{Start: 0x11800, End: 0x1184F, Name: "Dogra"},
+	{Start: 0x118A0, End: 0x118FF, Name: "Warang Citi"},
+	{Start: 0x11900, End: 0x1195F, Name: "Dives Akuru"},
+	{Start: 0x119A0, End: 0x119FF, Name: "Nandinagari"},
+	{Start: 0x11A00, End: 0x11A4F, Name: "Zanabazar Square"},
+	{Start: 0x11A50, End: 0x11AAF, Name: "Soyombo"},
+	{Start: 0x11AB0, End: 0x11ABF, Name: "Unified Canadian Aboriginal Syllabics Extended-A"},
+	{Start: 0x11AC0, End: 0x11AFF, Name: "Pau Cin Hau"},
+	{Start: 0x11C00, End: 0x11C6F, Name: "Bhaiksuki"},
+	{Start: 0x11C70, End: 0x11CBF, Name: "Marchen"},
+	{Start: 0x11D00, End: 0x11D5F, Name: "Masaram Gondi"},
+	{Start: 0x11D60, End: 0x11DAF, Name: "Gunjala Gondi"},
+	{Start: 0x11EE0, End: 0x11EFF, Name: "Makasar"},
+	{Start: 0x11FB0, End: 0x11FBF, Name: "Lisu Supplement"},
+	{Start: 0x11FC0, End: 0x11FFF, Name: "Tamil Supplement"},
+	{Start: 0x12000, End: 0x123FF, Name: "Cuneiform"},
+	{Start: 0x12400, End: 0x1247F, Name: "Cuneiform Numbers and Punctuation"},
+	{Start: 0x12480, End: 0x1254F, Name: "Early Dynastic Cuneiform"},
+	{Start: 0x12F90, End: 0x12FFF, Name: "Cypro-Minoan"},
+	{Start: 0x13000, End: 0x1342F, Name: "Egyptian Hieroglyphs"},
+	{Start: 0x13430, End: 0x1343F, Name: "Egyptian Hieroglyph Format Controls"},
+	{Start: 0x14400, End: 0x1467F, Name: "Anatolian Hieroglyphs"},
+	{Start: 0x16800, End: 0x16A3F, Name: "Bamum Supplement"},
+	{Start: 0x16A40, End: 0x16A6F, Name: "Mro"},
+	{Start: 0x16A70, End: 0x16ACF, Name: "Tangsa"},
+	{Start: 0x16AD0, End: 0x16AFF, Name: "Bassa Vah"},
+	{Start: 0x16B00, End: 0x16B8F, Name: "Pahawh Hmong"},
+	{Start: 0x16E40, End: 0x16E9F, Name: "Medefaidrin"},
+	{Start: 0x16F00, End: 0x16F9F, Name: "Miao"},
+	{Start: 0x16FE0, End: 0x16FFF, Name: "Ideographic Symbols and Punctuation"},
+	{Start: 0x17000, End: 0x187FF, Name: "Tangut"},
+	{Start: 0x18800, End: 0x18AFF, Name: "Tangut Components"},
+	{Start: 0x18B00, End: 0x18CFF, Name: "Khitan Small Script"},
+	{Start: 0x18D00, End: 0x18D7F, Name: "Tangut Supplement"},
+	{Start: 0x1AFF0, End: 0x1AFFF, Name: "Kana Extended-B"},
+	{Start: 0x1B000, End: 0x1B0FF, Name: "Kana Supplement"},
+	{Start: 0x1B100, End: 0x1B12F, Name: "Kana Extended-A"},
+	{Start: 0x1B130, End: 0x1B16F, Name: "Small Kana Extension"},
+	{Start: 0x1B170, End: 0x1B2FF, Name: "Nushu"},
+	{Start: 0x1BC00, End: 0x1BC9F, Name: "Duployan"},
+	{Start: 0x1BCA0, End: 0x1BCAF, Name: "Shorthand Format Controls"},
+	{Start: 0x1CF00, End: 0x1CFCF, Name: "Znamenny Musical Notation"},
+	{Start: 0x1D000, End: 0x1D0FF, Name: "Byzantine Musical Symbols"},
+	{Start: 0x1D100, End: 0x1D1FF, Name: "Musical Symbols"},
+	{Start: 0x1D200, End: 0x1D24F, Name: "Ancient Greek Musical Notation"},
+	{Start: 0x1D2E0, End: 0x1D2FF, Name: "Mayan Numerals"},
+	{Start: 0x1D300, End: 0x1D35F, Name: "Tai Xuan Jing Symbols"},
+	{Start: 0x1D360, End: 0x1D37F, Name: "Counting Rod Numerals"},
+	{Start: 0x1D400, End: 0x1D7FF, Name: "Mathematical Alphanumeric Symbols"},
+	{Start: 0x1D800, End: 0x1DAAF, Name: "Sutton SignWriting"},
+	{Start: 0x1DF00, End: 0x1DFFF, Name: "Latin Extended-G"},
+	{Start: 0x1E000, End: 0x1E02F, Name: "Glagolitic Supplement"},
+	{Start: 0x1E100, End: 0x1E14F, Name: "Nyiakeng Puachue Hmong"},
+	{Start: 0x1E290, End: 0x1E2BF, Name: "Toto"},
+	{Start: 0x1E2C0, End: 0x1E2FF, Name: "Wancho"},
+	{Start: 0x1E7E0, End: 0x1E7FF, Name: "Ethiopic Extended-B"},
+	{Start: 0x1E800, End: 0x1E8DF, Name: "Mende Kikakui"},
+	{Start: 0x1E900, End: 0x1E95F, Name: "Adlam"},
+	{Start: 0x1EC70, End: 0x1ECBF, Name: "Indic Siyaq Numbers"},
+	{Start: 0x1ED00, End: 0x1ED4F, Name: "Ottoman Siyaq Numbers"},
+	{Start: 0x1EE00, End: 0x1EEFF, Name: "Arabic Mathematical Alphabetic Symbols"},
+	{Start: 0x1F000, End: 0x1F02F, Name: "Mahjong Tiles"},
+	{Start: 0x1F030, End: 0x1F09F, Name: "Domino Tiles"},
+	{Start: 0x1F0A0, End: 0x1F0FF, Name: "Playing Cards"},
+	{Start: 0x1F100, End: 0x1F1FF, Name: "Enclosed Alphanumeric Supplement"},
+	{Start: 0x1F200, End: 0x1F2FF, Name: "Enclosed Ideographic Supplement"},
+	{Start: 0x1F300, End: 0x1F5FF, Name: "Miscellaneous Symbols and Pictographs"},
+	{Start: 0x1F600, End: 0x1F64F, Name: "Emoticons"},
+	{Start: 0x1F650, End: 0x1F67F, Name: "Ornamental Dingbats"},
+	{Start: 0x1F680, End: 0x1F6FF, Name: "Transport and Map Symbols"},
+	{Start: 0x1F700, End: 0x1F77F, Name: "Alchemical Symbols"},
+	{Start: 0x1F780, End: 0x1F7FF, Name: "Geometric Shapes Extended"},
+	{Start: 0x1F800, End: 0x1F8FF, Name: "Supplemental Arrows-C"},
+	{Start: 0x1F900, End: 0x1F9FF, Name: "Supplemental Symbols and Pictographs"},
+	{Start: 0x1FA00, End: 0x1FA6F, Name: "Chess Symbols"},
+	{Start: 0x1FA70, End: 0x1FAFF, Name: "Symbols and Pictographs Extended-A"},
+	{Start: 0x1FB00, End: 0x1FBFF, Name: "Symbols for Legacy Computing"},
+	{Start: 0x20000, End: 0x2A6DF, Name: "CJK Unified Ideographs Extension B"},
+	{Start: 0x2A700, End: 0x2B73F, Name: "CJK Unified Ideographs Extension C"},
+	{Start: 0x2B740, End: 0x2B81F, Name: "CJK Unified Ideographs Extension D"},
+	{Start: 0x2B820, End: 0x2CEAF, Name: "CJK Unified Ideographs Extension E"},
+	{Start: 0x2CEB0, End: 0x2EBEF, Name: "CJK Unified Ideographs Extension F"},
+	{Start: 0x2F800, End: 0x2FA1F, Name: "CJK Compatibility Ideographs Supplement"},
+	{Start: 0x30000, End: 0x3134F, Name: "CJK Unified Ideographs Extension G"},
+	{Start: 0xE0000, End: 0xE007F, Name: "Tags"},
+	{Start: 0xE0100, End: 0xE01EF, Name: "Variation Selectors Supplement"},
+	{Start: 0xF0000, End: 0xFFFFF, Name: "Supplementary Private Use Area-A"},
+	{Start: 0x100000, End: 0x10FFFF, Name: "Supplementary Private Use Area-B"},
+}
@@ -0,0 +1,126 @@
+//go:build ignore
+
+// genblocks generates the table of Unicode blocks from Blocks.txt of the
+// Unicode Character Database.
+//
+// Usage:
+//
+//	go run gen/genblocks.go [-in Blocks.txt] [-o blocks_gen.go]
+//
+// Without -in, Blocks.txt is downloaded from unicode.org.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const blocksURL = "https://www.unicode.org/Public/UCD/latest/ucd/Blocks.txt"
+
+type block struct {
+	start, end uint64
+	name       string
+}
+
+func main() {
+	var in, out string
+	flag.StringVar(&in, "in", "", `Blocks.txt to read (default: download from unicode.org)`)
+	flag.StringVar(&out, "o", "blocks_gen.go", `output file`)
+	flag.Parse()
+	if err := run(in, out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(in, out string) error {
+	rc, err := open(in)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	version, blocks, err := parse(rc)
+	if err != nil {
+		return err
+	}
+
+	bb := &bytes.Buffer{}
+	fmt.Fprintln(bb, "// Code generated by gen/genblocks.go. DO NOT EDIT.")
+	if version != "" {
+		fmt.Fprintf(bb, "// Source: %s\n", version)
+	}
+	fmt.Fprintln(bb)
+	fmt.Fprintln(bb, "package unicodeblocks")
+	fmt.Fprintln(bb)
+	fmt.Fprintln(bb, "// Blocks is the table of Unicode blocks, sorted by Start.")
+	fmt.Fprintln(bb, "var Blocks = []Block{")
+	for _, b := range blocks {
+		fmt.Fprintf(bb, "{Start: 0x%04X, End: 0x%04X, Name: %q},\n", b.start, b.end, b.name)
+	}
+	fmt.Fprintln(bb, "}")
+	src, err := format.Source(bb.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+func open(in string) (io.ReadCloser, error) {
+	if in != "" {
+		return os.Open(in)
+	}
+	resp, err := http.Get(blocksURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", blocksURL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// parse parses lines like "0000..007F; Basic Latin". version is the file
+// name in the first comment line, like "Blocks-14.0.0.txt".
+func parse(r io.Reader) (version string, blocks []block, err error) {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if n == 1 && strings.HasPrefix(line, "#") {
+			version = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		rng, name, ok := strings.Cut(line, ";")
+		if !ok {
+			return "", nil, fmt.Errorf("line %d: missing ';'", n)
+		}
+		first, last, ok := strings.Cut(strings.TrimSpace(rng), "..")
+		if !ok {
+			return "", nil, fmt.Errorf("line %d: invalid range %q", n, rng)
+		}
+		start, err := strconv.ParseUint(first, 16, 32)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		end, err := strconv.ParseUint(last, 16, 32)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		blocks = append(blocks, block{start: start, end: end, name: strings.TrimSpace(name)})
+	}
+	return version, blocks, sc.Err()
+}
@@ -0,0 +1,49 @@
+// Package unicodeblocks provides the table of Unicode blocks.
+package unicodeblocks
+
+//go:generate go run gen/genblocks.go -o blocks_gen.go
+
+import (
+	"sort"
+	"strings"
+)
+
+// Block is a named range of code points.
+type Block struct {
+	Start rune
+	End   rune // inclusive
+	Name  string
+}
+
+// Contains reports whether the rune r is in the block.
+func (b Block) Contains(r rune) bool {
+	return b.Start <= r && r <= b.End
+}
+
+// Of returns the block which contains the rune r. ok is false when r is not
+// in any block (No_Block).
+func Of(r rune) (b Block, ok bool) {
+	i := sort.Search(len(Blocks), func(i int) bool { return Blocks[i].End >= r })
+	if i < len(Blocks) && Blocks[i].Contains(r) {
+		return Blocks[i], true
+	}
+	return Block{}, false
+}
+
+// Lookup returns the block of the name. Names are compared loosely as
+// Blocks.txt specifies: casing, whitespace, hyphens and underbars are ignored.
+func Lookup(name string) (b Block, ok bool) {
+	key := looseName(name)
+	for _, b := range Blocks {
+		if looseName(b.Name) == key {
+			return b, true
+		}
+	}
+	return Block{}, false
+}
+
+var looseReplacer = strings.NewReplacer(" ", "", "\t", "", "-", "", "_", "")
+
+func looseName(s string) string {
+	return strings.ToLower(looseReplacer.Replace(s))
+}
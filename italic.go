@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// WithItalic slants the glyphs synthetically, by shearing them to the right
+// with bitimg.Image.Italicize. The cells and the advances are widened by
+// italicExtra pixels, and the slant of the XLFD is "O" (oblique).
+func WithItalic(slant float64) Option {
+	return func(cvt *BDFConverter) {
+		cvt.italic = slant
+	}
+}
+
+// italicExtra returns the number of pixels which WithItalic adds to the width
+// of the cells.
+func (cvt *BDFConverter) italicExtra() int {
+	return int(float64(cvt.height) * cvt.italic)
+}
+
+// italicShift returns the number of pixels which WithItalic shifts the row y
+// of a cell, as Italicize does.
+func (cvt *BDFConverter) italicShift(y int) int {
+	return int(float64(cvt.height-y) * cvt.italic)
+}
+
+// stylized reports whether any synthetic style applies to the glyphs.
+func (cvt *BDFConverter) stylized() bool {
+	return cvt.bold || cvt.strokeWidth > 0 || cvt.italic > 0
+}
+
+// stylize applies the synthetic styles of WithBold, WithStrokeWidth and
+// WithItalic to the rendered cell img, and returns the result, which is wider
+// by italicExtra pixels when italic.
+func (cvt *BDFConverter) stylize(img *bitimg.Image) *bitimg.Image {
+	if cvt.bold {
+		embolden(img)
+	}
+	if cvt.strokeWidth > 0 {
+		img = img.Dilate(cvt.strokeWidth / 2)
+	}
+	if cvt.italic > 0 {
+		img = img.Italicize(cvt.italic)
+	}
+	return img
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// renderedGlyph is a glyph rendered for writeBody.
+type renderedGlyph struct {
+	r       rune
+	adv     fixed.Int26_6
+	width   int
+	img     *bitimg.Image
+	elapsed time.Duration
+	err     error
+}
+
+// glyphRenderer renders glyphs with a font face. It has only the settings of
+// the converter to render glyphs, so the goroutines of WithJobs share nothing
+// with the converter but rateLimit, which is safe for concurrent use.
+type glyphRenderer struct {
+	face         font.Face
+	ascent       int
+	threshold    uint8
+	blankAsSpace bool
+	grayDir      string
+	rateLimit    *rateLimiter
+}
+
+// renderer returns a glyphRenderer of the converter with the face.
+func (cvt *BDFConverter) renderer(face font.Face) *glyphRenderer {
+	return &glyphRenderer{
+		face:         face,
+		ascent:       cvt.ascent,
+		threshold:    cvt.threshold,
+		blankAsSpace: cvt.blankAsSpace,
+		grayDir:      cvt.grayDir,
+		rateLimit:    cvt.rateLimit,
+	}
+}
+
+// newRenderer creates a glyphRenderer with another font face of the
+// converter, for goroutines other than the one which uses cvt.face. The face
+// must be closed after use.
+func (cvt *BDFConverter) newRenderer() (*glyphRenderer, error) {
+	face, err := cvt.openFace(cvt.fnt)
+	if err != nil {
+		return nil, err
+	}
+	return cvt.renderer(face), nil
+}
+
+// render clears img and draws the rune r on it.
+func (gr *glyphRenderer) render(img *bitimg.Image, r rune) {
+	img.Clear()
+	// Runes without glyphs are left blank.
+	_ = img.DrawGlyph(gr.face, r, gr.ascent)
+}
+
+// renderGlyph renders the glyph of the rune r to be written to BDF.
+func (gr *glyphRenderer) renderGlyph(img *bitimg.Image, r rune) {
+	gr.render(img, r)
+	gr.substituteBlank(img, r)
+}
+
+// substituteBlank renders the space on img, when img is the blank glyph of
+// the rune r, for WithBlankAsSpace.
+func (gr *glyphRenderer) substituteBlank(img *bitimg.Image, r rune) {
+	if gr.blankAsSpace && r != ' ' && img.IsBlank() {
+		slog.Debug("substituted space for blank glyph", "rune", fmt.Sprintf("U+%04X", r))
+		gr.render(img, ' ')
+	}
+}
+
+// renderOne renders the glyph g.r into g.img.
+func (gr *glyphRenderer) renderOne(g *renderedGlyph) {
+	if gr.rateLimit != nil {
+		gr.rateLimit.wait()
+	}
+	start := time.Now()
+	if gr.grayDir != "" {
+		g.err = gr.renderGrayscale(g.img, g.r)
+	} else {
+		gr.renderGlyph(g.img, g.r)
+	}
+	g.elapsed = time.Since(start)
+}
+
+// renderedGlyphs renders the glyphs to convert in order. With WithJobs(n) for
+// n > 1, n goroutines render the glyphs with their own font faces, otherwise
+// the glyphs are rendered one by one into fullImg and halfImg, which are
+// reused. The images of the yielded glyphs are valid only until the next
+// iteration either way.
+func (cvt *BDFConverter) renderedGlyphs(fullImg, halfImg *bitimg.Image) iter.Seq[*renderedGlyph] {
+	if cvt.jobs <= 1 {
+		return func(yield func(*renderedGlyph) bool) {
+			gr := cvt.renderer(cvt.face)
+			for r, adv := range cvt.bodyGlyphs() {
+				g := &renderedGlyph{r: r, adv: adv, width: cvt.cellWidth(adv), img: halfImg}
+				if g.width == cvt.fullWidth {
+					g.img = fullImg
+				}
+				gr.renderOne(g)
+				if !yield(g) {
+					return
+				}
+			}
+		}
+	}
+	return func(yield func(*renderedGlyph) bool) {
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(done)
+
+		type job struct {
+			g      *renderedGlyph
+			result chan *renderedGlyph
+		}
+		jobs := make(chan job)
+		// order has the results in the order of the glyphs, with a bounded
+		// number of glyphs in flight.
+		order := make(chan chan *renderedGlyph, cvt.jobs*4)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(order)
+			defer close(jobs)
+			for r, adv := range cvt.bodyGlyphs() {
+				width := cvt.cellWidth(adv)
+				g := &renderedGlyph{r: r, adv: adv, width: width, img: cvt.newCell(width)}
+				j := job{g: g, result: make(chan *renderedGlyph, 1)}
+				select {
+				case order <- j.result:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- j:
+				case <-done:
+					return
+				}
+			}
+		}()
+		for range cvt.jobs {
+			gr, err := cvt.newRenderer()
+			if err != nil {
+				// Report the error as of the first glyph.
+				yield(&renderedGlyph{err: err})
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer gr.face.Close()
+				for {
+					select {
+					case j, ok := <-jobs:
+						if !ok {
+							return
+						}
+						gr.renderOne(j.g)
+						j.result <- j.g
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+		for result := range order {
+			if !yield(<-result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestJobsSameOutput(t *testing.T) {
+	convert := func(jobs int) []byte {
+		cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithJobs(jobs))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cvt.Close()
+		b, err := cvt.ConvertToBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	if want, got := convert(1), convert(4); !bytes.Equal(got, want) {
+		t.Errorf("WithJobs(4) writes a BDF different from WithJobs(1)")
+	}
+}
+
+func benchmarkJobs(b *testing.B, jobs int) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithJobs(jobs))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer cvt.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cvt.ConvertWriter(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSequential(b *testing.B) { benchmarkJobs(b, 1) }
+
+func BenchmarkParallel(b *testing.B) { benchmarkJobs(b, runtime.NumCPU()) }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// kernPair is a precomposed glyph made of a base and a combining mark.
+type kernPair struct {
+	target rune
+	base   rune
+	mark   rune
+	dx, dy int
+}
+
+// readKernMap reads a map of combining sequences. Each line has hex code
+// points of the target, the base and the mark, optionally followed by the
+// offset of the mark in pixels: "00C1 0041 0301 [dx dy]". Empty lines and
+// lines starting with "#" are ignored.
+func readKernMap(r io.Reader) ([]kernPair, error) {
+	var pairs []kernPair
+	sc := bufio.NewScanner(r)
+	for lnum := 1; sc.Scan(); lnum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) != 3 && len(f) != 5 {
+			return nil, fmt.Errorf("line %d: want 3 or 5 fields, got %d", lnum, len(f))
+		}
+		var cps [3]rune
+		for i := range cps {
+			v, err := strconv.ParseUint(strings.TrimPrefix(f[i], "U+"), 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lnum, err)
+			}
+			cps[i] = rune(v)
+		}
+		p := kernPair{target: cps[0], base: cps[1], mark: cps[2]}
+		if len(f) == 5 {
+			var err error
+			if p.dx, err = strconv.Atoi(f[3]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lnum, err)
+			}
+			if p.dy, err = strconv.Atoi(f[4]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lnum, err)
+			}
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, sc.Err()
+}
+
+// ConvertKern writes a companion BDF to outName, where each entry of pairs
+// is the base glyph composited with the combining mark in a full width cell.
+// The mark is drawn at the advance of the base, where zero-width combining
+// marks are designed to overlap the preceding glyph. The composed glyphs are
+// styled and inverted as those of Convert, and cvt is left as it was.
+func (cvt *BDFConverter) ConvertKern(outName string, pairs []kernPair) error {
+	f, err := os.Create(outName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var dst io.Writer = f
+	var zw *gzip.Writer
+	if cvt.gzip || isGzipName(outName) {
+		zw = gzip.NewWriter(f)
+		dst = zw
+	}
+	w := bufio.NewWriter(dst)
+
+	// Skip pairs which the font can't render.
+	valid := make([]kernPair, 0, len(pairs))
+	for _, p := range pairs {
+		_, okBase := cvt.face.GlyphAdvance(p.base)
+		_, okMark := cvt.face.GlyphAdvance(p.mark)
+		if !okBase || !okMark {
+			slog.Warn("skipped a combining sequence with missing glyphs", "target", fmt.Sprintf("U+%04X", p.target))
+			continue
+		}
+		valid = append(valid, p)
+	}
+	pairs = valid
+
+	rect := image.Rect(0, 0, cvt.fullWidth, cvt.height)
+	img := bitimg.NewWithThreshold(rect, cvt.threshold)
+	mark := bitimg.NewWithThreshold(rect, cvt.threshold)
+	drawer := &font.Drawer{
+		Src:  image.NewUniform(color.White),
+		Face: cvt.face,
+	}
+	// All the composed glyphs are full width, and the glyph of DEFAULT_CHAR
+	// isn't written. Restore those of the main BDF for later conversions.
+	savedSpacing, savedDefaultChar := cvt.spacing, cvt.hasDefaultChar
+	defer func() { cvt.spacing, cvt.hasDefaultChar = savedSpacing, savedDefaultChar }()
+	cvt.spacing, cvt.hasDefaultChar = "M", false
+	width := cvt.fullWidth + cvt.italicExtra()
+	if err := cvt.writeHeaderWith(w, len(pairs), len(pairs)*width); err != nil {
+		return err
+	}
+	for _, p := range pairs {
+		img.Clear()
+		drawer.Dst = img
+		drawer.Dot = fixed.Point26_6{X: 0, Y: fixed.I(cvt.ascent)}
+		drawer.DrawString(string(p.base))
+
+		mark.Clear()
+		drawer.Dst = mark
+		drawer.Dot.Y = fixed.I(cvt.ascent)
+		drawer.DrawString(string(p.mark))
+		mark.Shift(p.dx, p.dy)
+		if err := img.Or(mark); err != nil {
+			return err
+		}
+
+		glyph := img
+		if cvt.stylized() {
+			glyph = cvt.stylize(glyph)
+		}
+		if cvt.invert {
+			glyph.Invert()
+		}
+		if err := cvt.writeGlyph(w, p.target, width, glyph); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "ENDFONT\n"); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// runKern reads the kern map file mapName and writes the companion BDF.
+func runKern(cvt *BDFConverter, mapName, outName string) error {
+	f, err := os.Open(mapName)
+	if err != nil {
+		return err
+	}
+	pairs, err := readKernMap(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", mapName, err)
+	}
+	return cvt.ConvertKern(outName, pairs)
+}
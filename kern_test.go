@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// convertKern writes the kern BDF of pairs with cvt, and parses it.
+func convertKern(t *testing.T, cvt *BDFConverter, pairs []kernPair) *bdf.Font {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "kern.bdf")
+	if err := cvt.ConvertKern(out, pairs); err != nil {
+		t.Fatal(err)
+	}
+	return parseBDFFile(t, out)
+}
+
+func TestConvertKernKeepsState(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}})), WithDefaultChar('A'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	want, err := cvt.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spacing, hasDefaultChar := cvt.spacing, cvt.hasDefaultChar
+	convertKern(t, cvt, []kernPair{{target: 0xC1, base: 'A', mark: 0xB4}})
+	if cvt.spacing != spacing || cvt.hasDefaultChar != hasDefaultChar {
+		t.Errorf("spacing %q and hasDefaultChar %v after ConvertKern; want %q and %v", cvt.spacing, cvt.hasDefaultChar, spacing, hasDefaultChar)
+	}
+	got, err := cvt.ConvertToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ConvertKern changed the BDF of the converter:\n%s", got)
+	}
+}
+
+func TestConvertKernStyles(t *testing.T) {
+	// A blank mark leaves the base as it is, in a full width cell as WithMono.
+	pairs := []kernPair{{target: 'A', base: 'A', mark: ' '}}
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"regular", nil},
+		{"bold", []Option{WithBold()}},
+		{"italic", []Option{WithItalic(0.3)}},
+		{"invert", []Option{WithInvert()}},
+		{"bold italic inverted", []Option{WithBold(), WithItalic(0.3), WithInvert()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			only := WithRuneFilter(inRuneRanges([]runeRange{{'A', 'A'}}))
+			want := findGlyph(t, convertGoRegular(t, 16, append(slices.Clip(tc.opts), only, WithMono())...), 'A')
+			cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cvt.Close()
+			got := findGlyph(t, convertKern(t, cvt, pairs), 'A')
+			if got.DWidth != want.DWidth || got.BBX != want.BBX || !slices.Equal(got.Bitmap, want.Bitmap) {
+				t.Errorf("DWIDTH %v, BBX %v, BITMAP %v; want %v, %v, %v", got.DWidth, got.BBX, got.Bitmap, want.DWidth, want.BBX, want.Bitmap)
+			}
+		})
+	}
+}
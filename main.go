@@ -4,31 +4,64 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"flag"
 	"fmt"
 	"image"
-	"image/color"
 	"io"
+	"io/fs"
 	"iter"
-	"log"
 	"log/slog"
+	"maps"
+	"math"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"github.com/koron/otf2ccbdf/internal/toml"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/font/sfnt"
 	"golang.org/x/image/math/fixed"
+	"golang.org/x/text/encoding/charmap"
 )
 
-func runeIter(face font.Face, filter func(rune) bool) iter.Seq2[rune, fixed.Int26_6] {
+// RuneIter returns an iterator over the runes which have glyphs in the face,
+// from U+0000 to U+10FFFF in ascending order, and their advances. Runes
+// rejected by filter are skipped; a nil filter accepts all runes.
+func RuneIter(face font.Face, filter func(rune) bool) iter.Seq2[rune, fixed.Int26_6] {
+	return runeIterOf(face, nil, filter)
+}
+
+// runeIterOf is RuneIter, which probes only the runes of candidates, sorted in
+// ascending order, instead of every code point. nil candidates are every code
+// point.
+func runeIterOf(face font.Face, candidates []rune, filter func(rune) bool) iter.Seq2[rune, fixed.Int26_6] {
 	if filter == nil {
 		filter = func(rune) bool { return true }
 	}
+	runes := slices.Values(candidates)
+	if candidates == nil {
+		runes = func(yield func(rune) bool) {
+			for r := rune(0); r <= unicode.MaxRune; r++ {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
 	return func(yield func(rune, fixed.Int26_6) bool) {
-		for r := rune(0); r <= 0xffff; r++ {
+		for r := range runes {
 			adv, ok := face.GlyphAdvance(r)
 			if !ok || !filter(r) {
 				continue
@@ -40,9 +73,17 @@ func runeIter(face font.Face, filter func(rune) bool) iter.Seq2[rune, fixed.Int2
 	}
 }
 
+// ErrNoCmap is returned when a font has no usable Unicode cmap subtable.
+var ErrNoCmap = errors.New("font has no Unicode cmap: neither 'A' nor 'a' has a glyph")
+
 type BDFConverter struct {
-	name string
+	name           string
+	postScriptName string
+
+	fnt  *sfnt.Font
+	raw  *sfnttab.Font
 	face font.Face
+	vm   *vmetrics
 
 	size      int
 	halfWidth int
@@ -51,192 +92,2026 @@ type BDFConverter struct {
 
 	ascent  int
 	descent int
+
+	bdfVersion  string
+	glyphPrefix string
+	postNames   bool
+
+	progress  ProgressFunc
+	timing    GlyphTimingFunc
+	pow2Width bool
+
+	blankAsSpace bool
+	checksum     bool
+	comments     []string
+	fontNameTmpl string
+	familyName   string
+	nameID       sfnt.NameID
+
+	nonNegativeDescent bool
+	hinting            font.Hinting
+	letterSpacing      int
+	fullWidthThreshold int
+	threshold          uint8
+
+	// halfCount and fullCount are the numbers of half and full width glyphs,
+	// counted by countGlyphs.
+	halfCount int
+	fullCount int
+	// spacing is the spacing of XLFD, determined by countGlyphs.
+	spacing string
+	// hasDefaultChar reports whether the glyph of DEFAULT_CHAR is written,
+	// determined by countGlyphs.
+	hasDefaultChar bool
+
+	subset []rune
+	// runes are the code points in the cmaps of the font and the fallback
+	// fonts, or nil to probe every code point.
+	runes        []rune
+	fallbackData [][]byte
+	fallbacks    []*sfnt.Font
+	fontXLFD     string
+	strict       bool
+	grayDir      string
+	debugDir     string
+	flushEvery   int
+	pixelScale   int
+	properties   map[string]string
+	dpi          int
+	pointSize    int
+	rotation     int
+	filter       func(rune) bool
+	maxChars     int
+	ranker       GlyphRanker
+
+	charsetRegistry string
+	charsetEncoding string
+	charmap         *charmap.Charmap
+	invert          bool
+	bold            bool
+	strokeWidth     float64
+	italic          float64
+	gzip            bool
+	snapAdvance     bool
+	rounding        Rounding
+	fontIndex       int
+	defaultChar     rune
+	mono            bool
+	skipBlank       bool
+	// blanks and blankGlyphs cache whether the glyphs of runes and unmapped
+	// glyphs are omitted by WithSkipBlank, found by glyphWidths.
+	blanks          map[rune]bool
+	blankGlyphs     map[sfnt.GlyphIndex]bool
+	jobs            int
+	faceOpts        *opentype.FaceOptions
+	unmapped        []sfnt.GlyphIndex
+	includeUnmapped bool
+	rateLimit       *rateLimiter
+	ascentOverride  int
+	glyphBBX        bool
+	tightBBX        bool
+	rowAlign        int
+	exactSWidth     bool
+	descentOverride int
+
+	mem memTracker
+
+	// observe is called with each glyph written to BDF, with -1 as the rune
+	// of the unmapped glyphs. img is valid only during the call.
+	observe func(r rune, adv fixed.Int26_6, width int, img *bitimg.Image)
+}
+
+// Option configures a BDFConverter.
+type Option func(*BDFConverter)
+
+// WithBDFVersion selects the BDF version to write: "2.1" (default) or "2.2".
+// BDF 2.2 adds vertical metrics (SWIDTH1, DWIDTH1 and VVECTOR).
+func WithBDFVersion(v string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.bdfVersion = v
+	}
+}
+
+// WithGlyphPrefix sets the prefix of the glyph names in STARTCHAR, which is
+// followed by the hex code point. The default is "U+".
+func WithGlyphPrefix(prefix string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.glyphPrefix = prefix
+	}
+}
+
+// WithPostNames names the glyphs in STARTCHAR after the post table of the
+// font, as "Aacute". The glyphs without a name there, including all those of
+// fonts without glyph names, are named as WithGlyphPrefix.
+func WithPostNames() Option {
+	return func(cvt *BDFConverter) {
+		cvt.postNames = true
+	}
+}
+
+// WithProgress sets a function to report the progress of the conversion.
+func WithProgress(fn ProgressFunc) Option {
+	return func(cvt *BDFConverter) {
+		cvt.progress = fn
+	}
+}
+
+// WithGlyphTiming sets a function to receive the rendering time of each glyph.
+func WithGlyphTiming(fn GlyphTimingFunc) Option {
+	return func(cvt *BDFConverter) {
+		cvt.timing = fn
+	}
+}
+
+// WithPow2Width pads the bitmap of each glyph to a power-of-two width. DWIDTH
+// keeps the natural advance, while BBX gets the padded width.
+func WithPow2Width() Option {
+	return func(cvt *BDFConverter) {
+		cvt.pow2Width = true
+	}
+}
+
+// WithBlankAsSpace substitutes the bitmap of the space (U+0020) for non-space
+// glyphs which are rendered blank.
+func WithBlankAsSpace() Option {
+	return func(cvt *BDFConverter) {
+		cvt.blankAsSpace = true
+	}
+}
+
+// WithChecksum appends a "COMMENT SHA256 <hex>" line before ENDFONT, which
+// records the SHA-256 of all preceding content.
+func WithChecksum() Option {
+	return func(cvt *BDFConverter) {
+		cvt.checksum = true
+	}
+}
+
+// WithFontNameTmpl sets the template of the family name in the FONT line.
+// "{family}", "{postscript_name}" and "{size}" are expanded. The default is
+// "{family}".
+func WithFontNameTmpl(tmpl string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.fontNameTmpl = tmpl
+	}
+}
+
+// WithFamilyName overrides the family name read from the name table.
+func WithFamilyName(name string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.familyName = name
+	}
+}
+
+// WithNameID selects the record of the name table for the family name, like
+// sfnt.NameIDFull. The default is sfnt.NameIDFamily. When the font has no
+// record of the ID, the family, the full and the PostScript names are tried
+// in order.
+func WithNameID(id sfnt.NameID) Option {
+	return func(cvt *BDFConverter) {
+		cvt.nameID = id
+	}
+}
+
+// WithAscent overrides the ascent of the font in pixels, which places the
+// baseline of the glyphs in the cell. Negative values are ignored.
+func WithAscent(ascent int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.ascentOverride = ascent
+	}
+}
+
+// WithDescent overrides the descent of the font in pixels, which is used for
+// the Y-offsets of BBX and FONTBOUNDINGBOX. Negative values are ignored.
+func WithDescent(descent int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.descentOverride = descent
+	}
+}
+
+// WithGlyphBBX makes BBX of each glyph the bounds of its outline, clipped by
+// the cell, instead of the cell. DWIDTH stays the cell width.
+func WithGlyphBBX() Option {
+	return func(cvt *BDFConverter) {
+		cvt.glyphBBX = true
+	}
+}
+
+// WithTightBBX makes BBX of each glyph the bounds of its set pixels, instead
+// of the cell, to make BDF smaller. DWIDTH stays the cell width, and blank
+// glyphs get BBX 0 0 0 0.
+func WithTightBBX() Option {
+	return func(cvt *BDFConverter) {
+		cvt.tightBBX = true
+	}
+}
+
+// WithRowAlign pads each row of BITMAP with zero bytes to a multiple of align
+// bytes, for firmware which expects rows aligned to words. BDF rows are not
+// padded beyond a byte by default, so consumers which follow BDF strictly,
+// like the check subcommand, reject the padded rows.
+func WithRowAlign(align int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.rowAlign = align
+	}
+}
+
+// WithExactSWidth computes SWIDTH from the advance of each glyph in the font,
+// with its fractional pixels, instead of from DWIDTH, which is the cell width.
+func WithExactSWidth() Option {
+	return func(cvt *BDFConverter) {
+		cvt.exactSWidth = true
+	}
+}
+
+// WithNonNegativeDescent shifts all glyphs upward by the descent, so the
+// Y-offsets of BBX and FONTBOUNDINGBOX become 0. FONT_ASCENT and FONT_DESCENT
+// follow them: the cell is above the baseline, so they are the cell height
+// and 0. This is for compatibility with consumers which can't parse negative
+// Y-offsets.
+func WithNonNegativeDescent() Option {
+	return func(cvt *BDFConverter) {
+		cvt.nonNegativeDescent = true
+	}
+}
+
+// WithHinting sets the hinting of the font face. The default is full hinting.
+func WithHinting(h font.Hinting) Option {
+	return func(cvt *BDFConverter) {
+		cvt.hinting = h
+	}
+}
+
+// parseHinting parses the name of a hinting mode: "none", "vertical" or
+// "full".
+func parseHinting(s string) (font.Hinting, error) {
+	switch s {
+	case "none":
+		return font.HintingNone, nil
+	case "vertical":
+		return font.HintingVertical, nil
+	case "full":
+		return font.HintingFull, nil
+	default:
+		return 0, fmt.Errorf("unknown hinting mode: %q", s)
+	}
+}
+
+// WithLetterSpacing adds n pixels to DWIDTH of each glyph. Bitmaps and
+// bounding boxes are not changed.
+func WithLetterSpacing(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.letterSpacing = n
+	}
+}
+
+// WithFullWidthThreshold sets the minimum advance in pixels for glyphs to be
+// classified as full width. The default is size/2 + 1.
+func WithFullWidthThreshold(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.fullWidthThreshold = n
+	}
+}
+
+// halfRatioThreshold returns the full width threshold of -half-threshold:
+// glyphs wider than the ratio of the size are full width.
+func halfRatioThreshold(size int, ratio float64) int {
+	return int(float64(size)*ratio) + 1
+}
+
+// WithThreshold sets the gray level of anti-aliased pixels, above which they
+// are set in the bitmaps. The default is bitimg.DefaultThreshold. A lower
+// threshold keeps thin strokes of light weight fonts at small sizes.
+func WithThreshold(t uint8) Option {
+	return func(cvt *BDFConverter) {
+		cvt.threshold = t
+	}
+}
+
+// WithSubset subsets the font to the runes before creating the font face, to
+// reduce the memory usage. Only the runes are converted. Subsetting supports
+// only fonts with TrueType outlines.
+func WithSubset(runes []rune) Option {
+	return func(cvt *BDFConverter) {
+		cvt.subset = runes
+	}
+}
+
+// WithFontXLFD sets the XLFD in the FONT line verbatim, instead of computing
+// it. The XLFD must have exactly 14 fields.
+func WithFontXLFD(s string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.fontXLFD = s
+	}
+}
+
+// WithStrict makes the conversion fail on problems which are warned by
+// default, such as glyphs overflowing their bounding boxes.
+func WithStrict() Option {
+	return func(cvt *BDFConverter) {
+		cvt.strict = true
+	}
+}
+
+// WithGrayscaleIntermediate saves the grayscale image of each glyph, before
+// it is thresholded, to "U+XXXX-gray.png" in the directory dir.
+func WithGrayscaleIntermediate(dir string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.grayDir = dir
+	}
+}
+
+// WithDebugGlyphs saves the bitmap of each glyph, as written to BDF, to
+// "U+XXXX.png" in the directory dir.
+func WithDebugGlyphs(dir string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.debugDir = dir
+	}
+}
+
+// WithFlushEvery flushes the output every n glyphs, when the writer has a
+// Flush method like bufio.Writer. It trades throughput for latency, e.g. to
+// stream BDF over a network. n <= 0 (default) leaves flushing to the writer.
+func WithFlushEvery(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.flushEvery = n
+	}
+}
+
+// WithPixelDoubling scales each glyph by n (n x n blocks per pixel) when it
+// is written, instead of rendering at a larger size. All dimensions in BDF,
+// including SIZE, are scaled accordingly. The letter spacing is added after
+// the scaling.
+func WithPixelDoubling(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.pixelScale = max(n, 1)
+	}
+}
+
+// WithProperties adds custom properties to the STARTPROPERTIES block, as
+// string values. Names must consist of upper case letters, digits and
+// underscores, like "X_VENDOR_CUSTOM_PROP".
+func WithProperties(props map[string]string) Option {
+	return func(cvt *BDFConverter) {
+		cvt.properties = maps.Clone(props)
+	}
+}
+
+// WithDPI sets the resolution of the font, 72 by default. The size stays in
+// pixels: the DPI determines the point size and the resolution in SIZE and
+// FONT.
+func WithDPI(dpi int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.dpi = dpi
+	}
+}
+
+// WithPointSize sets the point size in SIZE and FONT, instead of the one
+// derived from the pixel size and the DPI, which may be off by rounding. The
+// pixel size should be points * DPI / 72, so at 72 DPI a point is a pixel.
+func WithPointSize(points float64) Option {
+	return func(cvt *BDFConverter) {
+		cvt.pointSize = int(math.Round(points * 10))
+	}
+}
+
+// WithRuneFilter restricts the glyphs to convert to the runes accepted by fn.
+// Multiple filters are combined: a rune must be accepted by all of them.
+func WithRuneFilter(fn func(rune) bool) Option {
+	return func(cvt *BDFConverter) {
+		if prev := cvt.filter; prev != nil {
+			cvt.filter = func(r rune) bool { return prev(r) && fn(r) }
+			return
+		}
+		cvt.filter = fn
+	}
+}
+
+// WithInvert inverts the polarity of the bitmaps, for devices which expect
+// set bits as background.
+func WithInvert() Option {
+	return func(cvt *BDFConverter) {
+		cvt.invert = true
+	}
+}
+
+// WithSnapAdvance rounds the advance of each glyph to the nearest multiple of
+// the half width before classifying it as half or full width, so advances a
+// little off the cell widths are classified by the nearest cell.
+func WithSnapAdvance() Option {
+	return func(cvt *BDFConverter) {
+		cvt.snapAdvance = true
+	}
 }
 
-func newBDFConverter(name string, size int) (*BDFConverter, error) {
-	// Load a font from a file, determine its family name, and convert it to a font face.
+// WithFontIndex selects the font in a TrueType Collection (.ttc) by index. It
+// must be 0 for single fonts, which is the default.
+func WithFontIndex(index int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.fontIndex = index
+	}
+}
+
+// WithDefaultChar sets DEFAULT_CHAR, the code point of the glyph to show for
+// characters missing in the font. The default is U+0020. DEFAULT_CHAR is
+// omitted when the glyph isn't written, e.g. it is filtered out.
+func WithDefaultChar(r rune) Option {
+	return func(cvt *BDFConverter) {
+		cvt.defaultChar = r
+	}
+}
+
+// WithMono makes a genuinely monospaced BDF: every glyph gets the full width
+// cell, whatever its advance is.
+func WithMono() Option {
+	return func(cvt *BDFConverter) {
+		cvt.mono = true
+	}
+}
+
+// WithSkipBlank omits the glyphs whose bitmaps are blank, except for white
+// spaces.
+func WithSkipBlank() Option {
+	return func(cvt *BDFConverter) {
+		cvt.skipBlank = true
+		cvt.blanks = map[rune]bool{}
+		cvt.blankGlyphs = map[sfnt.GlyphIndex]bool{}
+	}
+}
+
+// WithJobs renders glyphs with n goroutines, each of which has its own font
+// face. The glyphs are still written in order. The default is 1.
+func WithJobs(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.jobs = n
+	}
+}
+
+// WithIncludeUnmapped also writes the glyphs which no code point maps to,
+// after the others, as "glyph<GID>" with ENCODING -1. Rune filters don't
+// apply to them.
+func WithIncludeUnmapped() Option {
+	return func(cvt *BDFConverter) {
+		cvt.includeUnmapped = true
+	}
+}
+
+// NewBDFConverter creates a converter of the OTF/TTF file name.
+func NewBDFConverter(name string, size int, opts ...Option) (*BDFConverter, error) {
 	b, err := os.ReadFile(name)
 	if err != nil {
 		return nil, err
 	}
-	fnt, err := opentype.Parse(b)
+	return NewBDFConverterFromBytes(b, size, opts...)
+}
+
+// Size returns the size of the font in pixels, given to NewBDFConverter.
+func (cvt *BDFConverter) Size() int { return cvt.size }
+
+// Ascent returns the ascent of the font in pixels.
+func (cvt *BDFConverter) Ascent() int { return cvt.ascent }
+
+// Descent returns the descent of the font in pixels.
+func (cvt *BDFConverter) Descent() int { return cvt.descent }
+
+// FamilyName returns the family name of the font, or the one given by
+// WithFamilyName.
+func (cvt *BDFConverter) FamilyName() string { return cvt.name }
+
+// newBDFConverterFromFS creates a converter of the OTF/TTF file name in fsys,
+// e.g. an embed.FS or a zip archive.
+func newBDFConverterFromFS(fsys fs.FS, name string, size int, opts ...Option) (*BDFConverter, error) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewBDFConverterFromBytes(b, size, opts...)
+}
+
+// NewBDFConverterFromBytes creates a converter of the OTF/TTF font data b,
+// e.g. embedded with go:embed. b must not be modified while the converter is
+// in use.
+func NewBDFConverterFromBytes(b []byte, size int, opts ...Option) (*BDFConverter, error) {
+	cvt := &BDFConverter{
+		size:        size,
+		halfWidth:   size / 2,
+		fullWidth:   size,
+		height:      size,
+		bdfVersion:  "2.1",
+		glyphPrefix: "U+",
+
+		fontNameTmpl: "{family}",
+		nameID:       sfnt.NameIDFamily,
+		hinting:      font.HintingFull,
+
+		fullWidthThreshold: size/2 + 1,
+		pixelScale:         1,
+		dpi:                72,
+		defaultChar:        ' ',
+		ascentOverride:     -1,
+		descentOverride:    -1,
+		threshold:          bitimg.DefaultThreshold,
+	}
+	for _, opt := range opts {
+		opt(cvt)
+	}
+	if cvt.bdfVersion != "2.1" && cvt.bdfVersion != "2.2" {
+		return nil, fmt.Errorf("unsupported BDF version: %s", cvt.bdfVersion)
+	}
+	if cvt.fontXLFD != "" {
+		if err := validateXLFD(cvt.fontXLFD); err != nil {
+			return nil, err
+		}
+	}
+	if cvt.dpi <= 0 {
+		return nil, fmt.Errorf("invalid DPI: %d", cvt.dpi)
+	}
+	for name := range cvt.properties {
+		if err := validatePropertyName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse the font, determine its family name, and convert it to a font face.
+	cvt.mem.start()
+	var err error
+	index := cvt.fontIndex
+	if cvt.subset != nil {
+		b, err = sfntSubset(b, index, cvt.subset)
+		if err != nil {
+			return nil, err
+		}
+		index = 0
+	}
+	fnt, err := parseFont(b, index)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := parseRaw(b, index)
 	if err != nil {
 		return nil, err
 	}
-	familyName, err := fnt.Name(nil, sfnt.NameIDFamily)
+	cvt.mem.stats.FontBytes = cvt.mem.sample()
+	familyName := cvt.familyName
+	if familyName == "" {
+		familyName, err = lookupFamilyName(raw, fnt, cvt.nameID)
+		if err != nil {
+			slog.Warn("Failed to get family name, so fell back to \"Unknown\"", "err", err)
+			familyName = "Unknown"
+		}
+	}
+	postScriptName, err := preferredName(raw, fnt, sfnt.NameIDPostScript)
 	if err != nil {
-		slog.Warn("Failed to get family name, so fell back to \"Unknown\"", "err", err)
-		familyName = "Unknown"
+		slog.Warn("Failed to get PostScript name", "err", err)
 	}
-	face, err := opentype.NewFace(fnt, &opentype.FaceOptions{
-		Size:    float64(size),
-		DPI:     72,
-		Hinting: font.HintingFull,
-	})
+	// Hinting makes no sense for bitmaps, and sfnt renders only outlines, so
+	// the glyphs of bitmap-only fonts come out blank.
+	if nativeSizes, bitmapOnly, err := DetectBitmapFont(raw); err != nil {
+		slog.Warn("Failed to read embedded bitmaps", "err", err)
+	} else if bitmapOnly {
+		slog.Warn("the font has only embedded bitmaps, which are not rendered", "nativeSizes", nativeSizes)
+		cvt.hinting = font.HintingNone
+		if !slices.Contains(nativeSizes, size) {
+			slog.Warn("the size is not a native bitmap size", "size", size, "nativeSizes", nativeSizes)
+		}
+	}
+	// Render at size pixels, whatever the DPI is.
+	cvt.faceOpts = &opentype.FaceOptions{
+		Size:    float64(size) * 72 / float64(cvt.dpi),
+		DPI:     float64(cvt.dpi),
+		Hinting: cvt.hinting,
+	}
+	raws := []*sfnttab.Font{raw}
+	for _, fb := range cvt.fallbackData {
+		f, err := parseFont(fb, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fallback font: %w", err)
+		}
+		cvt.fallbacks = append(cvt.fallbacks, f)
+		if r, err := parseRaw(fb, 0); err == nil {
+			raws = append(raws, r)
+		} else {
+			raws = append(raws, nil)
+		}
+	}
+	cvt.runes = cmapRunes(raws...)
+	face, err := cvt.openFace(fnt)
 	if err != nil {
 		return nil, err
 	}
+	if d := cvt.mem.sample(); d > cvt.mem.stats.FontBytes {
+		cvt.mem.stats.FaceBytes = d - cvt.mem.stats.FontBytes
+	}
+	// Use 'A' and 'a' as canaries to detect a missing or non-Unicode cmap.
+	// Subsets may lack both, but Subset has already read the Unicode cmap.
+	if cvt.subset == nil {
+		_, okUpper := face.GlyphAdvance('A')
+		_, okLower := face.GlyphAdvance('a')
+		if !okUpper && !okLower {
+			face.Close()
+			return nil, ErrNoCmap
+		}
+	}
+
+	if cvt.bdfVersion == "2.2" {
+		cvt.vm = readVMetrics(raw)
+	}
+
+	cvt.name = familyName
+	cvt.postScriptName = postScriptName
+	cvt.fnt = fnt
+	cvt.raw = raw
+	cvt.face = face
+	if cvt.maxChars > 0 {
+		cvt.limitChars()
+	}
+	if cvt.includeUnmapped {
+		cvt.unmapped, err = cvt.unmappedGlyphs()
+		if err != nil {
+			face.Close()
+			return nil, err
+		}
+	}
+	cvt.ascent = face.Metrics().Ascent.Round()
+	cvt.descent = face.Metrics().Descent.Round()
+	if cvt.ascentOverride >= 0 {
+		cvt.ascent = cvt.ascentOverride
+	}
+	if cvt.descentOverride >= 0 {
+		cvt.descent = cvt.descentOverride
+	}
+	if cvt.ascent+cvt.descent > cvt.height {
+		slog.Warn("font metrics exceed the cell height, glyphs may be clipped",
+			"font", cvt.name, "size", size,
+			"ascent", cvt.ascent, "descent", cvt.descent, "height", cvt.height)
+	}
+	return cvt, nil
+}
+
+// verticalAdvance returns the vertical advance of the rune in pixels. It
+// falls back to the cell height when the font has no vertical metrics.
+func (cvt *BDFConverter) verticalAdvance(r rune) int {
+	if cvt.vm == nil {
+		return cvt.height
+	}
+	gid, err := cvt.fnt.GlyphIndex(nil, r)
+	if err != nil || gid == 0 {
+		return cvt.height
+	}
+	return cvt.glyphVerticalAdvance(gid)
+}
 
-	return &BDFConverter{
-		name:      familyName,
-		face:      face,
-		size:      size,
-		halfWidth: size / 2,
-		fullWidth: size,
-		height:    size,
-		ascent:    face.Metrics().Ascent.Round(),
-		descent:   face.Metrics().Descent.Round(),
-	}, nil
+// glyphVerticalAdvance returns the vertical advance of the glyph gid in
+// pixels. cvt.vm must not be nil.
+func (cvt *BDFConverter) glyphVerticalAdvance(gid sfnt.GlyphIndex) int {
+	adv := cvt.vm.advance(int(gid)) * cvt.size
+	upem := int(cvt.fnt.UnitsPerEm())
+	return (adv + upem/2) / upem
 }
 
+// Close closes the font face, and returns its error.
 func (cvt *BDFConverter) Close() error {
 	return cvt.face.Close()
 }
 
+// closeKeepErr closes c, and stores the error of Close to *errp unless *errp
+// already has an error. It is used with defer, to not discard errors of
+// Close.
+func closeKeepErr(c io.Closer, errp *error) {
+	if err := c.Close(); err != nil && *errp == nil {
+		*errp = err
+	}
+}
+
+// Warm renders the first n glyphs, or the printable ASCII characters when n
+// <= 0, and discards the results, to prime the caches of the font face.
+func (cvt *BDFConverter) Warm(n int) error {
+	img := cvt.newCell(cvt.fullWidth)
+	if n <= 0 {
+		for r := rune(0x20); r <= 0x7e; r++ {
+			cvt.render(img, r)
+		}
+		return nil
+	}
+	for r := range cvt.glyphs() {
+		if n <= 0 {
+			break
+		}
+		cvt.render(img, r)
+		n--
+	}
+	return nil
+}
+
+// renderGlyph renders the glyph of the rune r to be written to BDF.
+func (cvt *BDFConverter) renderGlyph(img *bitimg.Image, r rune) {
+	cvt.renderer(cvt.face).renderGlyph(img, r)
+}
+
+// render clears img and draws the rune r on it.
+func (cvt *BDFConverter) render(img *bitimg.Image, r rune) {
+	cvt.renderer(cvt.face).render(img, r)
+}
+
+// defaultOutName returns the automatic output file name: "{family}-{size}px.bdf",
+// with the family name sanitized by sanitizeFileName.
+func (cvt *BDFConverter) defaultOutName() string {
+	return fmt.Sprintf("%s-%dpx.bdf", sanitizeFileName(cvt.name), cvt.outSize())
+}
+
+// sanitizeFileName replaces spaces in s with underscores, and removes the
+// characters which common file systems don't allow in file names.
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ':
+			return '_'
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// fontName expands the template of the family name in the FONT line.
+func (cvt *BDFConverter) fontName() string {
+	return strings.NewReplacer(
+		"{family}", cvt.name,
+		"{postscript_name}", cvt.postScriptName,
+		"{size}", strconv.Itoa(cvt.outSize()),
+	).Replace(cvt.fontNameTmpl)
+}
+
 // Convert converts the font to BDF and write it to the file outName.
 func (cvt *BDFConverter) Convert(outName string) error {
-	// Open the output file with buffering
 	f, err := os.Create(outName)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	w := bufio.NewWriter(f)
-	defer w.Flush()
-	if err := cvt.writeHeader(w); err != nil {
+	if cvt.gzip || isGzipName(outName) {
+		err = cvt.convertGzip(f)
+	} else {
+		err = cvt.ConvertWriter(f)
+	}
+	if err != nil {
 		return err
 	}
-	return cvt.writeBody(w)
+	return f.Close()
 }
 
-var headTmpl = template.Must(template.New("head").Parse(`STARTFONT 2.1
-FONT -FreeType-{{.name}}-Medium-R-Normal--{{.pixelSize}}-{{.pointSize}}-72-72-C-{{.averageWidth}}-ISO10646-1
-SIZE {{.size}} 72 72
-FONTBOUNDINGBOX {{.width}} {{.height}} 0 {{.descent}}
-CHARS {{.chars}}
-`))
-
-// writeHeader Writes the BDF header
-func (cvt *BDFConverter) writeHeader(w io.Writer) error {
-	// Count the glyphs and calculate their average width
-	var (
-		glyphCount = 0
-		widthSum   = 0
-	)
-	for _, adv := range runeIter(cvt.face, nil) {
-		glyphCount++
-		if adv.Round() > cvt.halfWidth {
-			widthSum += cvt.fullWidth
-		} else {
-			widthSum += cvt.halfWidth
-		}
+// ConvertWriter converts the font to BDF and writes it to w with buffering,
+// e.g. to an http.ResponseWriter or a pipe.
+func (cvt *BDFConverter) ConvertWriter(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if err := cvt.write(bw); err != nil {
+		return err
 	}
-
-	return headTmpl.Execute(w, map[string]any{
-		"name":         cvt.name,
-		"pixelSize":    int(((float64(cvt.size) * 10 * 72) / 722.7) + 0.5),
-		"pointSize":    cvt.size * 10,
-		"averageWidth": widthSum * 10 / glyphCount,
-		"size":         cvt.size,
-		"width":        cvt.fullWidth,
-		"height":       cvt.height,
-		"descent":      -cvt.descent,
-		"chars":        glyphCount,
-	})
+	return bw.Flush()
 }
 
-var bodyTmpl = template.Must(template.New("body").Parse(`
-STARTCHAR U+{{printf "%04X" .rune}}
-ENCODING {{.rune}}
-DWIDTH {{.width}} 0
-BBX {{.width}} {{.height}} 0 {{.descent}}
-BITMAP
-{{.bitmap -}}
-ENDCHAR
-`))
-
-// writeBody writes the BDF body (glyphs)
-func (cvt *BDFConverter) writeBody(w io.Writer) error {
-	fullImg := bitimg.New(image.Rect(0, 0, cvt.fullWidth, cvt.height))
-	halfImg := bitimg.New(image.Rect(0, 0, cvt.halfWidth, cvt.height))
-	drawer := &font.Drawer{
-		Src:  image.NewUniform(color.White),
-		Face: cvt.face,
-		Dot:  fixed.Point26_6{},
-	}
-
-	for r, adv := range runeIter(cvt.face, nil) {
-		var (
-			width = cvt.halfWidth
-			img   = halfImg
-		)
-		if adv.Round() > cvt.halfWidth {
-			width = cvt.fullWidth
-			img = fullImg
-		}
-
-		img.Clear()
-		drawer.Dst = img
-		drawer.Dot = fixed.Point26_6{X: 0, Y: fixed.I(cvt.ascent)}
-		drawer.DrawString(fmt.Sprintf("%c", r))
-
-		// Output a character
-		bb := &bytes.Buffer{}
-		b := img.Bytes()
-		xn := img.Xn()
-		for len(b) > 0 {
-			fmt.Fprintf(bb, "%X\n", b[:xn])
-			b = b[xn:]
-		}
-		err := bodyTmpl.Execute(w, map[string]any{
-			"rune":    r,
-			"width":   width,
-			"height":  cvt.height,
-			"descent": -cvt.descent,
-			"bitmap":  bb.String(),
-		})
-		if err != nil {
-			return err
-		}
+// ConvertToBytes converts the font to BDF and returns it as bytes.
+func (cvt *BDFConverter) ConvertToBytes() ([]byte, error) {
+	bb := &bytes.Buffer{}
+	if err := cvt.write(bb); err != nil {
+		return nil, err
 	}
-	return nil
+	return bb.Bytes(), nil
 }
 
-// Run converts a OTF/TTF to BDF.
-func Run(ctx context.Context, args []string) error {
-	var (
-		inName  string
-		outName string
-		size    int
-	)
-
+// write writes whole BDF to w.
+func (cvt *BDFConverter) write(w io.Writer) error {
+	h := sha256.New()
+	cw := w
+	if cvt.checksum {
+		cw = io.MultiWriter(w, h)
+	}
+	start := time.Now()
+	total, err := cvt.writeHeader(cw)
+	if err != nil {
+		return err
+	}
+	slog.Info("conversion started", "font", cvt.name, "size", cvt.size, "glyphs", total)
+	var flush func() error
+	if f, ok := w.(interface{ Flush() error }); ok {
+		flush = f.Flush
+	}
+	if err := cvt.writeBody(cw, total, flush); err != nil {
+		return err
+	}
+	if cvt.checksum {
+		if _, err := fmt.Fprintf(w, "%s%x\n", checksumPrefix, h.Sum(nil)); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "ENDFONT\n"); err != nil {
+		return err
+	}
+	slog.Info("conversion finished", "font", cvt.name, "size", cvt.size, "glyphs", total, "elapsed", time.Since(start))
+	return nil
+}
+
+var headTmpl = template.Must(template.New("head").Parse(`STARTFONT {{.version}}
+{{range .comments}}COMMENT {{.}}
+{{end -}}
+FONT {{.xlfd}}
+SIZE {{.size}} {{.dpi}} {{.dpi}}
+FONTBOUNDINGBOX {{.width}} {{.height}} 0 {{.descent}}
+{{- if .vertical}}
+METRICSSET 2
+VVECTOR {{.vvectorX}} {{.vvectorY}}
+{{- end}}
+{{- if .properties}}
+STARTPROPERTIES {{len .properties}}
+{{- range .properties}}
+{{.}}
+{{- end}}
+ENDPROPERTIES
+{{- end}}
+CHARS {{.chars}}
+`))
+
+// glyphs returns an iterator over the runes to convert and their advances.
+func (cvt *BDFConverter) glyphs() iter.Seq2[rune, fixed.Int26_6] {
+	return runeIterOf(cvt.face, cvt.runes, cvt.filter)
+}
+
+// cmapRunes returns the code points in the cmaps of the fonts, sorted without
+// duplicates. It returns nil when a cmap can't be read, e.g. it has only the
+// subtables which sfnttab doesn't parse, to fall back to probing every code
+// point.
+func cmapRunes(raws ...*sfnttab.Font) []rune {
+	var runes []rune
+	for _, raw := range raws {
+		if raw == nil {
+			return nil
+		}
+		mappings, err := raw.CMap()
+		if err != nil {
+			slog.Debug("probing every code point, as the cmap can't be read", "err", err)
+			return nil
+		}
+		for _, m := range mappings {
+			runes = append(runes, m.Rune)
+		}
+	}
+	slices.Sort(runes)
+	return slices.Compact(runes)
+}
+
+// isFullWidth reports whether a glyph with the advance is full width.
+func (cvt *BDFConverter) isFullWidth(adv fixed.Int26_6) bool {
+	px := roundAdv(adv, cvt.rounding)
+	if cvt.snapAdvance && cvt.halfWidth > 0 {
+		px = (px + cvt.halfWidth/2) / cvt.halfWidth * cvt.halfWidth
+	}
+	return px >= cvt.fullWidthThreshold
+}
+
+// cellWidth returns the width of the cell for a glyph with the advance.
+func (cvt *BDFConverter) cellWidth(adv fixed.Int26_6) int {
+	if cvt.mono || cvt.isFullWidth(adv) {
+		return cvt.fullWidth
+	}
+	return cvt.halfWidth
+}
+
+// newCell returns a blank image of a cell of the width, to render a glyph
+// into with the threshold.
+func (cvt *BDFConverter) newCell(width int) *bitimg.Image {
+	return bitimg.NewWithThreshold(image.Rect(0, 0, width, cvt.height), cvt.threshold)
+}
+
+// countGlyphs counts the glyphs and sums up their widths. It records the
+// numbers of half and full width glyphs for GlyphWidthStats, the spacing of
+// XLFD, and whether the glyph of DEFAULT_CHAR is written.
+func (cvt *BDFConverter) countGlyphs() (glyphCount, widthSum int) {
+	cvt.halfCount, cvt.fullCount = 0, 0
+	cvt.hasDefaultChar = false
+	widths := map[int]bool{}
+	for r, width := range cvt.glyphWidths() {
+		glyphCount++
+		if r == cvt.defaultChar {
+			cvt.hasDefaultChar = true
+		}
+		widthSum += width + cvt.italicExtra()
+		if width == cvt.fullWidth {
+			cvt.fullCount++
+		} else {
+			cvt.halfCount++
+		}
+		if !unicode.IsSpace(r) {
+			widths[width] = true
+		}
+	}
+	cvt.spacing = cvt.xlfdSpacing(widths)
+	return glyphCount, widthSum
+}
+
+// GlyphWidthStats returns the numbers of half and full width glyphs of the
+// last conversion. Many CJK glyphs counted as half width tell that the
+// threshold of full width doesn't fit the font.
+func (cvt *BDFConverter) GlyphWidthStats() (half, full int) {
+	return cvt.halfCount, cvt.fullCount
+}
+
+// Count counts the glyphs to convert, in total and by their cell widths,
+// without writing anything.
+func (cvt *BDFConverter) Count() (glyphCount, halfWidthCount, fullWidthCount int, err error) {
+	for _, width := range cvt.glyphWidths() {
+		glyphCount++
+		if width == cvt.fullWidth {
+			fullWidthCount++
+		} else {
+			halfWidthCount++
+		}
+	}
+	return glyphCount, halfWidthCount, fullWidthCount, nil
+}
+
+// glyphWidths returns an iterator over the runes and the cell widths of the
+// glyphs which writeBody writes. Unmapped glyphs have -1 as their runes.
+func (cvt *BDFConverter) glyphWidths() iter.Seq2[rune, int] {
+	return func(yield func(rune, int) bool) {
+		var img *bitimg.Image
+		for r, adv := range cvt.glyphs() {
+			width := cvt.cellWidth(adv)
+			if cvt.skipBlank {
+				blank, ok := cvt.blanks[r]
+				if !ok {
+					// Render to know the glyphs to be skipped by writeBody.
+					if img == nil || img.Bounds().Dx() != width {
+						img = cvt.newCell(width)
+					}
+					cvt.renderGlyph(img, r)
+					blank = cvt.skipsGlyph(r, img)
+					cvt.blanks[r] = blank
+					if blank {
+						slog.Debug("skipped blank glyph", "rune", fmt.Sprintf("U+%04X", r))
+					}
+				}
+				if blank {
+					continue
+				}
+			}
+			if !yield(r, width) {
+				return
+			}
+		}
+		for _, gid := range cvt.unmapped {
+			adv, err := cvt.glyphIndexAdvance(gid)
+			if err != nil {
+				continue
+			}
+			width := cvt.cellWidth(adv)
+			if cvt.skipBlank {
+				blank, ok := cvt.blankGlyphs[gid]
+				if !ok {
+					if img == nil || img.Bounds().Dx() != width {
+						img = cvt.newCell(width)
+					}
+					err := cvt.renderGlyphIndex(img, gid)
+					blank = err != nil || cvt.skipsGlyph(-1, img)
+					cvt.blankGlyphs[gid] = blank
+				}
+				if blank {
+					continue
+				}
+			}
+			if !yield(-1, width) {
+				return
+			}
+		}
+	}
+}
+
+// skipsGlyph reports whether the glyph of the rune r rendered as img is
+// omitted by WithSkipBlank.
+func (cvt *BDFConverter) skipsGlyph(r rune, img *bitimg.Image) bool {
+	return cvt.skipBlank && !unicode.IsSpace(r) && img.IsBlank()
+}
+
+// bodyGlyphs returns an iterator over the glyphs to render for writeBody:
+// those of glyphs, without the blank glyphs found by glyphWidths, so they are
+// not rendered again.
+func (cvt *BDFConverter) bodyGlyphs() iter.Seq2[rune, fixed.Int26_6] {
+	return func(yield func(rune, fixed.Int26_6) bool) {
+		for r, adv := range cvt.glyphs() {
+			if !cvt.blanks[r] && !yield(r, adv) {
+				return
+			}
+		}
+	}
+}
+
+// writeHeader Writes the BDF header, and returns the number of glyphs.
+func (cvt *BDFConverter) writeHeader(w io.Writer) (int, error) {
+	// Count the glyphs and calculate their average width
+	glyphCount, widthSum := cvt.countGlyphs()
+	return glyphCount, cvt.writeHeaderWith(w, glyphCount, widthSum)
+}
+
+// writeHeaderWith writes the BDF header with the number of glyphs and the sum
+// of their widths.
+func (cvt *BDFConverter) writeHeaderWith(w io.Writer, glyphCount, widthSum int) error {
+	comments := slices.Clone(cvt.comments)
+	if t, err := headTableModified(cvt.raw); err == nil {
+		comments = append(comments, "Font modified: "+t.Format(time.RFC3339))
+	} else {
+		slog.Debug("omitted the modified time", "font", cvt.name, "err", err)
+	}
+	n := cvt.pixelScale
+	if cvt.swapsAxes() {
+		widthSum = glyphCount * cvt.height
+	}
+	averageWidth := 0
+	if glyphCount > 0 {
+		averageWidth = widthSum * n * 10 / glyphCount
+	}
+	boxWidth, boxHeight := cvt.fontBox()
+
+	xlfd, properties := cvt.fontLine(averageWidth), cvt.propertyLines()
+	if n := len("FONT ") + len(xlfd); n > maxLineLength {
+		return fmt.Errorf("FONT line is too long: %d bytes exceeds %d", n, maxLineLength)
+	}
+	for _, line := range properties {
+		if len(line) > maxLineLength {
+			return fmt.Errorf("property line is too long: %d bytes exceeds %d", len(line), maxLineLength)
+		}
+	}
+
+	return headTmpl.Execute(w, map[string]any{
+		"comments":   comments,
+		"version":    cvt.bdfVersion,
+		"vertical":   cvt.bdfVersion == "2.2",
+		"vvectorX":   cvt.fullWidth * n / 2,
+		"vvectorY":   cvt.ascent * n,
+		"xlfd":       xlfd,
+		"size":       (cvt.deciPointSize() + 5) / 10,
+		"dpi":        cvt.dpi,
+		"width":      boxWidth * n,
+		"height":     boxHeight * n,
+		"descent":    -cvt.fontDescent(),
+		"chars":      glyphCount,
+		"properties": properties,
+	})
+}
+
+var bodyTmpl = template.Must(template.New("body").Parse(`
+STARTCHAR {{.name}}
+ENCODING {{.encoding}}
+SWIDTH {{.swidth}} 0
+DWIDTH {{.dwidth}} 0
+{{- if .vertical}}
+SWIDTH1 0 {{.swidth1}}
+DWIDTH1 0 {{.dwidth1}}
+{{- end}}
+BBX {{.bbxWidth}} {{.height}} {{.xoff}} {{.descent}}
+BITMAP
+{{.bitmap -}}
+ENDCHAR
+`))
+
+// writeBody writes the BDF body (glyphs). total is the number of glyphs, used
+// to report the progress. flush, if not nil, is called every cvt.flushEvery
+// glyphs.
+func (cvt *BDFConverter) writeBody(w io.Writer, total int, flush func() error) error {
+	if cvt.rateLimit != nil {
+		cvt.rateLimit.start()
+		defer cvt.rateLimit.stop()
+	}
+	fullImg := cvt.newCell(cvt.fullWidth)
+	halfImg := cvt.newCell(cvt.halfWidth)
+	cvt.mem.stats.BufferBytes = uint64(len(fullImg.Bytes()) + len(halfImg.Bytes()))
+
+	debug := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	var issues glyphIssues
+	done := 0
+	// written is called after each glyph is written.
+	written := func(r rune, elapsed time.Duration) error {
+		done++
+		if flush != nil && cvt.flushEvery > 0 && done%cvt.flushEvery == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if done%memSampleInterval == 0 {
+			cvt.mem.sample()
+		}
+		if cvt.progress != nil {
+			cvt.progress(done, total)
+		}
+		if cvt.timing != nil {
+			cvt.timing(done, total, r, elapsed)
+		}
+		return nil
+	}
+	for g := range cvt.renderedGlyphs(fullImg, halfImg) {
+		if g.err != nil {
+			return g.err
+		}
+		r, adv, width, img, elapsed := g.r, g.adv, g.width, g.img, g.elapsed
+		if cvt.skipsGlyph(r, img) {
+			slog.Debug("skipped blank glyph", "rune", fmt.Sprintf("U+%04X", r))
+			continue
+		}
+		if err := cvt.checkGlyphFit(r, width, &issues); err != nil {
+			return err
+		}
+		if adv == 0 {
+			slog.Debug("glyph has zero advance", "rune", fmt.Sprintf("U+%04X", r))
+			issues.zeroAdvance++
+		}
+		if unicode.IsGraphic(r) && !unicode.IsSpace(r) && img.IsBlank() {
+			slog.Debug("glyph of non-space rune is blank", "rune", fmt.Sprintf("U+%04X", r))
+			issues.blank++
+		}
+		if debug {
+			slog.Debug("rendered glyph", "rune", fmt.Sprintf("U+%04X", r), "width", width, "elapsed", elapsed)
+		}
+		if cvt.stylized() {
+			img = cvt.stylize(img)
+			width += cvt.italicExtra()
+		}
+		if cvt.invert {
+			img.Invert()
+		}
+		if cvt.debugDir != "" {
+			if err := writeDebugPNG(filepath.Join(cvt.debugDir, fmt.Sprintf("U+%04X.png", r)), img); err != nil {
+				return err
+			}
+		}
+
+		if err := cvt.writeGlyph(w, r, width, img); err != nil {
+			return err
+		}
+		if cvt.observe != nil {
+			cvt.observe(r, adv, width, img)
+		}
+		if err := written(r, elapsed); err != nil {
+			return err
+		}
+	}
+	if err := cvt.writeUnmapped(w, &issues, written); err != nil {
+		return err
+	}
+	issues.warn(cvt.name)
+	return nil
+}
+
+// writeGlyph writes a glyph entry of the rune r with the bitmap img.
+func (cvt *BDFConverter) writeGlyph(w io.Writer, r rune, width int, img *bitimg.Image) error {
+	vadv := 0
+	if cvt.bdfVersion == "2.2" {
+		vadv = cvt.verticalAdvance(r)
+	}
+	if cvt.glyphBBX {
+		var rect image.Rectangle
+		if b, _, ok := cvt.face.GlyphBounds(r); ok {
+			rect = cvt.outlineRect(b)
+		}
+		img = cvt.cropGlyphBBX(img, rect)
+	}
+	var adv fixed.Int26_6
+	if cvt.exactSWidth {
+		adv, _ = cvt.face.GlyphAdvance(r)
+	}
+	return cvt.writeEntry(w, cvt.glyphName(r), cvt.encoding(r), vadv, width, adv, img)
+}
+
+// glyphName returns the name of the glyph of r in STARTCHAR.
+func (cvt *BDFConverter) glyphName(r rune) string {
+	if cvt.postNames {
+		var buf sfnt.Buffer
+		if gid, err := cvt.fnt.GlyphIndex(&buf, r); err == nil && gid != 0 {
+			if name, err := cvt.fnt.GlyphName(&buf, gid); err == nil && name != "" {
+				return name
+			}
+		}
+	}
+	return fmt.Sprintf("%s%04X", cvt.glyphPrefix, r)
+}
+
+// outlineRect returns the pixels which the outline bounds b of a glyph cover,
+// in the coordinates of its cell.
+func (cvt *BDFConverter) outlineRect(b fixed.Rectangle26_6) image.Rectangle {
+	return image.Rect(b.Min.X.Floor(), cvt.ascent+b.Min.Y.Floor(), b.Max.X.Ceil(), cvt.ascent+b.Max.Y.Ceil())
+}
+
+// cropGlyphBBX crops the styled cell img to rect of outlineRect for
+// WithGlyphBBX, extended by the pixels which the styles add.
+func (cvt *BDFConverter) cropGlyphBBX(img *bitimg.Image, rect image.Rectangle) *bitimg.Image {
+	if cvt.bold && !rect.Empty() {
+		// Include the column added by embolden.
+		rect.Max.X++
+	}
+	if rad := int(cvt.strokeWidth / 2); rad > 0 && !rect.Empty() {
+		// Include the pixels added by Dilate, within the cell.
+		rect = rect.Inset(-rad).Intersect(img.Bounds())
+	}
+	if cvt.italic > 0 && !rect.Empty() {
+		rect.Min.X += cvt.italicShift(rect.Max.Y - 1)
+		rect.Max.X += cvt.italicShift(rect.Min.Y)
+	}
+	return img.Crop(rect)
+}
+
+// writeEntry writes a glyph entry with the name, the encoding and the
+// vertical advance, which is used only by BDF 2.2. adv is the advance for
+// WithExactSWidth, or 0 to compute SWIDTH from DWIDTH. img is the cell of the
+// glyph, or a part of it for a smaller BBX.
+func (cvt *BDFConverter) writeEntry(w io.Writer, name string, encoding, vadv, width int, adv fixed.Int26_6, img *bitimg.Image) error {
+	cellHeight := cvt.height
+	if cvt.rotation != 0 {
+		img, width, cellHeight = cvt.rotateCell(img, width)
+		if cvt.swapsAxes() {
+			// The advance is no longer along DWIDTH.
+			adv = 0
+		}
+	}
+	if cvt.tightBBX {
+		img = img.Crop(img.InkBounds())
+	}
+	n := cvt.pixelScale
+	bounds := img.Bounds()
+	var xoff, yoff int
+	if !bounds.Empty() {
+		xoff, yoff = bounds.Min.X*n, (cvt.yOffset()+cellHeight-bounds.Max.Y)*n
+		img = cvt.scaleImage(img)
+	}
+	width *= n
+	bbxWidth, height := bounds.Dx()*n, bounds.Dy()*n
+
+	if cvt.pow2Width {
+		bbxWidth = nextPow2(bbxWidth)
+		img = img.Pad(bbxWidth, height)
+		// Keep a smaller BBX in the cell, by padding it on the left instead.
+		if d := xoff + bbxWidth - (cvt.fullWidth+cvt.italicExtra())*n; d > 0 && xoff > 0 {
+			d = min(d, xoff)
+			xoff -= d
+			img.Shift(d, 0)
+		}
+	}
+
+	// Output a character. Each row is written as two hex digits per byte,
+	// followed by the padding of WithRowAlign.
+	padding := ""
+	if align := cvt.rowAlign; align > 1 && img.Xn()%align != 0 {
+		padding = strings.Repeat("00", align-img.Xn()%align)
+	}
+	if n := 2*img.Xn() + len(padding); n > maxLineLength {
+		return fmt.Errorf("%s: BITMAP row is too long: %d bytes exceeds %d", name, n, maxLineLength)
+	}
+	bb := &bytes.Buffer{}
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		fmt.Fprintf(bb, "%X%s\n", img.Row(y), padding)
+	}
+	swidth := cvt.scalableWidth(width + cvt.letterSpacing)
+	if adv > 0 {
+		swidth = cvt.exactScalableWidth(adv*fixed.Int26_6(n) + fixed.I(cvt.letterSpacing))
+	}
+	data := map[string]any{
+		"name":     name,
+		"encoding": encoding,
+		"swidth":   swidth,
+		"dwidth":   width + cvt.letterSpacing,
+		"bbxWidth": bbxWidth,
+		"height":   height,
+		"xoff":     xoff,
+		"descent":  yoff,
+		"bitmap":   bb.String(),
+	}
+	if cvt.bdfVersion == "2.2" {
+		data["vertical"] = true
+		data["swidth1"] = -vadv * 1000 / cvt.size
+		data["dwidth1"] = -vadv * n
+	}
+	return bodyTmpl.Execute(w, data)
+}
+
+// scalableWidth returns SWIDTH for DWIDTH dwidth, in 1/1000 of the point
+// size: dwidth * 1000 * 72 / (point size * DPI), that is dwidth * 1000 / pixel
+// size.
+func (cvt *BDFConverter) scalableWidth(dwidth int) int {
+	size := cvt.outSize()
+	return (dwidth*1000 + size/2) / size
+}
+
+// exactScalableWidth returns SWIDTH for the advance adv in pixels of BDF, as
+// scalableWidth does, but without rounding adv to pixels first.
+func (cvt *BDFConverter) exactScalableWidth(adv fixed.Int26_6) int {
+	size := int64(cvt.outSize())
+	return int((int64(adv)*1000 + size*32) / (size * 64))
+}
+
+// outSize returns the size of the font in BDF, which is scaled by
+// WithPixelDoubling.
+func (cvt *BDFConverter) outSize() int {
+	return cvt.size * cvt.pixelScale
+}
+
+// deciPointSize returns the size of the font in BDF in tenths of points, at
+// the DPI, or the one of WithPointSize.
+func (cvt *BDFConverter) deciPointSize() int {
+	if cvt.pointSize > 0 {
+		return cvt.pointSize * cvt.pixelScale
+	}
+	return (cvt.outSize()*720 + cvt.dpi/2) / cvt.dpi
+}
+
+// scaleImage returns img scaled by WithPixelDoubling.
+func (cvt *BDFConverter) scaleImage(img *bitimg.Image) *bitimg.Image {
+	switch cvt.pixelScale {
+	case 1:
+		return img
+	case 2:
+		return bitimg.PixelDouble(img)
+	}
+	b := img.Bounds()
+	return img.Resize(b.Dx()*cvt.pixelScale, b.Dy()*cvt.pixelScale, bitimg.NearestNeighbor, 0)
+}
+
+// yOffset returns the Y-offset of the bounding boxes of glyphs.
+func (cvt *BDFConverter) yOffset() int {
+	if cvt.nonNegativeDescent {
+		return 0
+	}
+	return -cvt.descent
+}
+
+// fontDescent returns the descent of FONTBOUNDINGBOX in the pixels of BDF,
+// which FONT_DESCENT agrees with.
+func (cvt *BDFConverter) fontDescent() int {
+	return -cvt.yOffset() * cvt.pixelScale
+}
+
+// suffixedOutName inserts suffix before the ".bdf" extension of outName. The
+// extension ".bdf.gz" of a gzipped outName is kept.
+func suffixedOutName(outName, suffix string) string {
+	ext := ".bdf"
+	if isGzipName(outName) {
+		outName, ext = strings.TrimSuffix(outName, ".gz"), ".bdf.gz"
+	}
+	return strings.TrimSuffix(outName, ".bdf") + suffix + ext
+}
+
+// nextPow2 returns the smallest power of two which is not less than n.
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// subcommands are the subcommands of Run, selected by the first argument.
+var subcommands = map[string]func(args []string) error{
+	"bdf2svg":   runBDF2SVG,
+	"check":     runCheck,
+	"coverage":  runCoverage,
+	"font-info": runFontInfo,
+	"metrics":   runMetrics,
+	"preview":   runPreview,
+}
+
+// Run converts a OTF/TTF to BDF.
+func Run(ctx context.Context, args []string) (err error) {
+	if len(args) > 0 {
+		if cmd, ok := subcommands[args[0]]; ok {
+			return cmd(args[1:])
+		}
+	}
+
+	var (
+		inName      string
+		outName     string
+		outputDir   string
+		format      string
+		watch       bool
+		glyphBBX    bool
+		tightBBX    bool
+		rowAlign    int
+		exactSWidth bool
+		config      string
+		configInput int
+		dryRun      bool
+		size        int
+
+		bdfVersion  string
+		glyphPrefix string
+		postNames   bool
+		progressBar bool
+		verbose     bool
+		quiet       bool
+		pow2Width   bool
+		timing      bool
+
+		blankAsSpace   bool
+		checksum       bool
+		comments       []string
+		commentFile    string
+		licenseFile    string
+		autoLicense    bool
+		verifyChecksum string
+		exportBundle   string
+		kernMap        string
+		fontNameTmpl   string
+		compareMetrics string
+		dumpTables     bool
+		familyName     string
+		nameID         int
+		exportGIDMap   string
+		memStats       bool
+		nonNegDescent  bool
+		emitUnhinted   bool
+		hintThreshold  int
+		letterSpacing  int
+		fullThreshold  int
+		halfThreshold  float64
+		threshold      uint
+		subsetFile     string
+		verify         bool
+		verifyTol      float64
+		fontXLFD       string
+		strict         bool
+		grayDir        string
+		debugDir       string
+		pixelDoubling  int
+		pixelDouble    bool
+		heatmap        string
+		dpi            int
+		pointSize      float64
+		maxChars       int
+		rotate         int
+		ranges         []runeRange
+		charsFile      string
+		bmpOnly        bool
+		hinting        string
+		rounding       string
+		invert         bool
+		bold           bool
+		strokeWidth    float64
+		italic         float64
+		charset        string
+		gzipOut        bool
+		noKerning      bool
+		fontIndex      int
+		defaultChar    int
+		mono           bool
+		skipBlank      bool
+		jobs           int
+		warm           int
+		unmapped       bool
+		splitByBlock   bool
+		ascent         int
+		descent        int
+		rateLimit      int
+	)
+
 	fs := flag.NewFlagSet("", flag.ExitOnError)
 	fs.StringVar(&outName, "out", "", `output name`)
-	fs.IntVar(&size, "size", 16, `font size`)
+	fs.StringVar(&outputDir, "output-dir", "", `directory to write "{family}-{size}px.bdf" in, instead of -out`)
+	fs.StringVar(&config, "config", "", `TOML file of the flags, with "input" and "fallbacks" for the arguments, and [[inputs]] for multiple conversions`)
+	fs.IntVar(&configInput, "config-input", -1, `index of [[inputs]] of -config to convert, all when negative`)
+	fs.BoolVar(&glyphBBX, "glyph-bbx", false, `use the outline bounds of each glyph as its BBX, instead of the cell`)
+	fs.IntVar(&rowAlign, "row-align", 1, `pad BITMAP rows to a multiple of 1, 2 or 4 bytes, for firmware`)
+	fs.IntVar(&rotate, "rotate", 0, `rotate the glyphs clockwise by 0, 90, 180 or 270 degrees, for rotated displays`)
+	fs.IntVar(&maxChars, "max-chars", 0, `convert only the first N glyphs in code point order, after the other filters, 0 for all`)
+	fs.BoolVar(&exactSWidth, "exact-swidth", false, `compute SWIDTH from the advance of each glyph with its fractional pixels, instead of from DWIDTH`)
+	fs.BoolVar(&tightBBX, "tight-bbx", false, `use the bounds of the set pixels of each glyph as its BBX, instead of the cell`)
+	fs.BoolVar(&watch, "watch", false, `convert again whenever the font file changes, until interrupted`)
+	fs.BoolVar(&dryRun, "dry-run", false, `print the number of glyphs and their average width without writing output`)
+	fs.Func("comment", `text of a COMMENT line after STARTFONT, split into lines of at most 1023 bytes (repeatable)`, func(s string) error {
+		comments = append(comments, s)
+		return nil
+	})
+	fs.StringVar(&commentFile, "comment-file", "", `text file of COMMENT lines after STARTFONT, each split into lines of at most 1023 bytes`)
+	fs.StringVar(&licenseFile, "license-file", "", `license file of the font to embed as COMMENT lines`)
+	fs.BoolVar(&autoLicense, "auto-license", false, `embed LICENSE, LICENSE.txt or OFL.txt next to the font as COMMENT lines`)
+	fs.StringVar(&format, "format", "bdf", `output format: "bdf", "pcf" or "psf" (PSF2, every glyph gets the full width)`)
+	fs.IntVar(&size, "size", 16, `font size in pixels`)
+	fs.IntVar(&size, "pixel-size", 16, `same as -size`)
+	fs.Float64Var(&pointSize, "point-size", 0, `font size in points, which is converted to pixels at -dpi and rounded to a multiple of 2: at 72 DPI a point is a pixel`)
+	fs.StringVar(&glyphPrefix, "glyph-prefix", "U+", `prefix of glyph names in STARTCHAR`)
+	fs.BoolVar(&postNames, "post-names", false, `name glyphs in STARTCHAR after the post table, or as -glyph-prefix without a name there`)
+	fs.StringVar(&bdfVersion, "bdf-version", "2.1", `BDF version to write: "2.1" or "2.2" (adds vertical metrics)`)
+	fs.IntVar(&fontIndex, "font-index", 0, `index of the font in a TrueType Collection (.ttc)`)
+	fs.IntVar(&ascent, "ascent", -1, `override the ascent of the font in pixels`)
+	fs.IntVar(&descent, "descent", -1, `override the descent of the font in pixels`)
+	fs.StringVar(&familyName, "family-name", "", `override the family name of the font`)
+	fs.IntVar(&nameID, "name-id", int(sfnt.NameIDFamily), `ID of the name record for the family name: 1 (family) to 6 (PostScript)`)
+	fs.StringVar(&familyName, "font-name", "", `alias of -family-name, which must not be set with it`)
+	fs.StringVar(&fontXLFD, "font-xlfd", "", `XLFD to use verbatim in the FONT line`)
+	fs.StringVar(&fontNameTmpl, "font-name-tmpl", "{family}", `template of the family name in FONT: {family}, {postscript_name} and {size} are expanded`)
+	fs.IntVar(&defaultChar, "default-char", ' ', `code point of DEFAULT_CHAR`)
+	fs.Func("range", `code point range to convert, like "U+0020-U+007E" (repeatable)`, func(s string) error {
+		rr, err := parseRuneRange(s)
+		if err != nil {
+			return err
+		}
+		ranges = append(ranges, rr)
+		return nil
+	})
+	fs.StringVar(&charsFile, "chars-file", "", `text file of the characters to convert: a character, "U+XXXX" or "U+XXXX-U+YYYY" per line`)
+	fs.BoolVar(&bmpOnly, "encode-unicode-range", false, `skip code points above U+FFFF, for X11 which rejects larger ENCODING`)
+	fs.StringVar(&hinting, "hinting", "full", `hinting mode of advances and metrics: "none", "vertical" or "full" (outlines are never hinted)`)
+	fs.StringVar(&rounding, "rounding", "round", `rounding of advances to pixels to classify glyphs: "round", "floor" or "ceil"`)
+	fs.StringVar(&subsetFile, "subset", "", `text file of the characters to subset the font to before the conversion`)
+	fs.IntVar(&fullThreshold, "fullwidth-threshold", 0, `minimum advance in pixels for full width glyphs (default size/2+1)`)
+	fs.Float64Var(&halfThreshold, "half-threshold", 0.5, `ratio to -size of the maximum advance of half width glyphs`)
+	fs.UintVar(&threshold, "threshold", bitimg.DefaultThreshold, `gray level of anti-aliased pixels above which they are set (0-254)`)
+	fs.IntVar(&dpi, "dpi", 72, `resolution in SIZE and FONT: -size stays in pixels`)
+	fs.IntVar(&pixelDoubling, "pixel-doubling", 1, `scale each pixel to an NxN block: 1, 2 or 3`)
+	fs.BoolVar(&pixelDouble, "pixel-double", false, `scale each pixel to a 2x2 block, same as -pixel-doubling 2`)
+	fs.IntVar(&letterSpacing, "letter-spacing", 0, `pixels to add to DWIDTH of each glyph`)
+	fs.BoolVar(&blankAsSpace, "emit-blank-as-space", false, `use the space glyph's bitmap for blank non-space glyphs`)
+	fs.BoolVar(&nonNegDescent, "non-negative-descent", false, `shift glyphs up so BBX Y-offsets are never negative`)
+	fs.BoolVar(&noKerning, "no-kerning", false, `snap glyph advances to the nearest half width before classifying them as half or full width`)
+	fs.IntVar(&rateLimit, "rate-limit", 0, `maximum number of glyphs rendered per second, 0 for no limit`)
+	fs.BoolVar(&unmapped, "include-unmapped", false, `also write glyphs without code points, with ENCODING -1`)
+	fs.BoolVar(&splitByBlock, "split-by-block", false, `write a BDF file per Unicode block, like "font.Basic_Latin.bdf" for -out font.bdf`)
+	fs.BoolVar(&skipBlank, "skip-blank", false, `omit glyphs with blank bitmaps, except for white spaces`)
+	fs.BoolVar(&mono, "mono", false, `use the full width for every glyph, to make a monospaced BDF`)
+	fs.BoolVar(&invert, "invert", false, `invert the polarity of glyph bitmaps`)
+	fs.BoolVar(&gzipOut, "gz", false, `compress the BDF with gzip, and add ".gz" to -out (implied by -out ending with ".gz")`)
+	fs.BoolVar(&bold, "bold", false, `make glyphs bold by OR-ing them with themselves shifted right by a pixel`)
+	fs.StringVar(&charset, "charset", "ISO10646-1", `CHARSET_REGISTRY-CHARSET_ENCODING of the XLFD, like ISO8859-1 (ENCODING is remapped for 8-bit charsets)`)
+	fs.Float64Var(&strokeWidth, "stroke-width", 0, `thicken strokes of glyphs by the pixels, by dilating them with a disk`)
+	fs.Float64Var(&italic, "italic", 0, `slant glyphs by shearing them right by the ratio to the height, like 0.2`)
+	fs.BoolVar(&pow2Width, "pow2-width", false, `pad glyph bitmaps to a power-of-two width`)
+	fs.BoolVar(&checksum, "checksum", false, `append a SHA-256 checksum comment`)
+	fs.StringVar(&verifyChecksum, "verify-checksum", "", `verify the checksum of a BDF file and exit`)
+	fs.StringVar(&exportBundle, "export-bundle", "", `export a zip archive with BDF, atlas PNG, metrics CSV and JSON report`)
+	fs.StringVar(&kernMap, "kern-bdf", "", `map file of combining sequences, to emit a companion "-kern.bdf" with precomposed glyphs`)
+	fs.StringVar(&compareMetrics, "compare-metrics", "", `compare the metrics with another OTF/TTF file and exit`)
+	fs.BoolVar(&dumpTables, "dump-font-tables", false, `print the SFNT tables of the font and exit`)
+	fs.StringVar(&heatmap, "density-heatmap", "", `write a PNG heatmap of how often each pixel of a cell is set across glyphs`)
+	fs.StringVar(&exportGIDMap, "export-gid-map", "", `write a TSV which maps glyph indices to code points`)
+	fs.BoolVar(&emitUnhinted, "emit-unhinted", false, `also write an unhinted "-unhinted.bdf" and log hinting-affected glyphs`)
+	fs.IntVar(&hintThreshold, "hinting-diff-threshold", 4, `number of differing pixels to log a glyph as hinting-affected`)
+	fs.BoolVar(&verify, "verify", false, `verify the output by comparing its bitmaps with re-rendered glyphs`)
+	fs.Float64Var(&verifyTol, "verify-tolerance", 0, `acceptable pixel error rate of -verify, e.g. 0.01 for 1%`)
+	fs.BoolVar(&strict, "strict", false, `fail on problems which are warned by default`)
+	fs.StringVar(&debugDir, "debug-glyphs", "", `directory to save PNG files of the glyph bitmaps`)
+	fs.StringVar(&grayDir, "grayscale-intermediate", "", `directory to save grayscale PNG previews of glyphs before thresholding`)
+	fs.IntVar(&jobs, "jobs", runtime.NumCPU(), `number of goroutines to render glyphs, each with its own font face; the output doesn't depend on it`)
+	fs.IntVar(&warm, "warm", 0, `number of glyphs to render before the conversion, to prime the caches of the font face`)
+	fs.BoolVar(&quiet, "quiet", false, `print only errors`)
+	fs.BoolVar(&verbose, "v", false, `report the progress as "done/total" glyphs`)
+	fs.BoolVar(&progressBar, "progress-bar", false, `show a progress bar (logs the progress when stderr is not a terminal)`)
+	fs.BoolVar(&timing, "timing", false, `log glyph rendering time statistics`)
+	fs.BoolVar(&memStats, "mem-stats", false, `log memory usage after the conversion`)
 	fs.Parse(args)
 
-	if fs.NArg() == 0 {
+	// The flags on the command line override the config file.
+	fontArgs := fs.Args()
+	if config != "" {
+		cfg, err := loadConfig(config)
+		if err != nil {
+			return err
+		}
+		inputs := configInputs(cfg)
+		tables := []toml.Table{cfg}
+		if len(inputs) > 0 {
+			if configInput < 0 {
+				if watch {
+					return errors.New("-watch doesn't support [[inputs]] of -config")
+				}
+				return runConfigInputs(ctx, args, len(inputs))
+			}
+			if configInput >= len(inputs) {
+				return fmt.Errorf("-config-input %d is out of [[inputs]]", configInput)
+			}
+			tables = []toml.Table{inputs[configInput], cfg}
+		}
+		cfgArgs, err := applyConfig(fs, tables...)
+		if err != nil {
+			return err
+		}
+		if len(fontArgs) == 0 {
+			fontArgs = cfgArgs
+		}
+	}
+
+	if quiet {
+		defer slog.SetLogLoggerLevel(slog.SetLogLoggerLevel(slog.LevelError))
+		progressBar, verbose = false, false
+	}
+
+	if verifyChecksum != "" {
+		return runVerifyChecksum(verifyChecksum)
+	}
+
+	if len(fontArgs) == 0 {
 		return errors.New("an argument is required: the OTF/TTF file to convert to BDF")
 	}
-	inName = fs.Arg(0)
-	if outName == "" {
+	inName = fontArgs[0]
+	if watch {
+		// Run again without -watch, which must come before the arguments.
+		flagArgs := args[:len(args)-fs.NArg()]
+		watchArgs := append(slices.Concat(flagArgs, []string{"-watch=false"}), fs.Args()...)
+		return runWatch(ctx, inName, watchArgs)
+	}
+	if dumpTables {
+		return dumpFontTables(os.Stdout, inName)
+	}
+	if outName != "" && outputDir != "" {
+		return errors.New("-out conflicts with -output-dir")
+	}
+	// The flags which are set, to check the conflicts of the flags which share
+	// variables or have defaults. applyConfig sets the flags of the config
+	// with fs.Set, so Visit finds them too.
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["family-name"] && set["font-name"] {
+		return errors.New("-font-name conflicts with -family-name")
+	}
+	if outName == "" && outputDir == "" && exportBundle == "" && compareMetrics == "" && !dryRun {
 		return errors.New("-out must be specified")
 	}
+	if pointSize != 0 {
+		if set["size"] || set["pixel-size"] {
+			return errors.New("-point-size conflicts with -size and -pixel-size")
+		}
+		if pointSize < 0 || dpi <= 0 {
+			return errors.New("-point-size and -dpi must be positive")
+		}
+		// -size must be a multiple of 2, so round to the nearest one.
+		size = 2 * int(math.Round(pointSize*float64(dpi)/72/2))
+		if size == 0 {
+			return fmt.Errorf("-point-size %g is less than a pixel at %d DPI", pointSize, dpi)
+		}
+	}
 	if size%2 == 1 {
 		return errors.New("-size must be a multiple of 2")
 	}
+	if pixelDouble {
+		if pixelDoubling != 1 && pixelDoubling != 2 {
+			return errors.New("-pixel-double conflicts with -pixel-doubling")
+		}
+		pixelDoubling = 2
+	}
+	if pixelDoubling < 1 || pixelDoubling > 3 {
+		return errors.New("-pixel-doubling must be 1, 2 or 3")
+	}
+	if maxChars < 0 {
+		return errors.New("-max-chars must not be negative")
+	}
+	if warm < 0 {
+		return errors.New("-warm must not be negative")
+	}
+	if rowAlign != 1 && rowAlign != 2 && rowAlign != 4 {
+		return errors.New("-row-align must be 1, 2 or 4")
+	}
+	if nameID < 1 || nameID > 6 {
+		return errors.New("-name-id must be 1 to 6")
+	}
+	if halfThreshold <= 0 || halfThreshold >= 1 {
+		return errors.New("-half-threshold must be between 0 and 1")
+	}
+	if threshold > 254 {
+		return errors.New("-threshold must be 0 to 254")
+	}
+
+	if verify && format != "bdf" {
+		return errors.New("-verify supports only -format bdf")
+	}
+	if gzipOut && format != "bdf" {
+		return errors.New("-gz supports only -format bdf")
+	}
+	if italic < 0 {
+		return errors.New("-italic must not be negative")
+	}
+	if strokeWidth < 0 {
+		return errors.New("-stroke-width must not be negative")
+	}
+	if italic > 0 && format == "psf" {
+		return errors.New("-italic doesn't support -format psf, which has a fixed cell width")
+	}
+	if rotate != 0 && rotate != 90 && rotate != 180 && rotate != 270 {
+		return errors.New("-rotate must be 0, 90, 180 or 270")
+	}
+	if rotate != 0 && (format != "bdf" || verify || bdfVersion != "2.1" || glyphBBX || pow2Width) {
+		return errors.New("-rotate supports only -format bdf and -bdf-version 2.1, without -verify, -glyph-bbx and -pow2-width")
+	}
+	if splitByBlock && (format != "bdf" || verify) {
+		return errors.New("-split-by-block supports only -format bdf, without -verify")
+	}
+
+	hintingMode, err := parseHinting(hinting)
+	if err != nil {
+		return err
+	}
+	roundingMode, err := parseRounding(rounding)
+	if err != nil {
+		return err
+	}
+
+	opts := []Option{
+		WithBDFVersion(bdfVersion),
+		WithHinting(hintingMode),
+		WithRounding(roundingMode),
+		WithGlyphPrefix(glyphPrefix),
+		WithFontNameTmpl(fontNameTmpl),
+		WithFamilyName(familyName),
+		WithNameID(sfnt.NameID(nameID)),
+		WithAscent(ascent),
+		WithDescent(descent),
+		WithLetterSpacing(letterSpacing),
+		WithFontXLFD(fontXLFD),
+		WithDPI(dpi),
+		WithPointSize(pointSize),
+		WithFontIndex(fontIndex),
+		WithDefaultChar(rune(defaultChar)),
+		WithJobs(jobs),
+	}
+	if strict {
+		opts = append(opts, WithStrict())
+	}
+	if postNames {
+		opts = append(opts, WithPostNames())
+	}
+	if licenseFile == "" && autoLicense {
+		if licenseFile = findLicenseFile(inName); licenseFile == "" {
+			slog.Warn("no license file next to the font", "font", inName)
+		}
+	}
+	if licenseFile != "" {
+		// The license comes first, before the other comments.
+		text, err := readCommentFile(licenseFile)
+		if err != nil {
+			return err
+		}
+		comments = append([]string{text}, comments...)
+	}
+	if commentFile != "" {
+		text, err := readCommentFile(commentFile)
+		if err != nil {
+			return err
+		}
+		comments = append(comments, text)
+	}
+	if len(comments) > 0 {
+		opts = append(opts, WithComments(comments...))
+	}
+	for _, name := range fontArgs[1:] {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithFallbackFonts(b))
+	}
+	if len(ranges) > 0 {
+		if err := checkRuneRanges(ranges); err != nil {
+			return err
+		}
+		opts = append(opts, WithRuneFilter(inRuneRanges(ranges)))
+	}
+	if charsFile != "" {
+		chars, err := readCharsFile(charsFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithRuneFilter(func(r rune) bool { return chars[r] }))
+	}
+	if bmpOnly {
+		opts = append(opts, WithRuneFilter(func(r rune) bool { return r <= 0xffff }))
+	}
+	if pixelDoubling > 1 {
+		opts = append(opts, WithPixelDoubling(pixelDoubling))
+	}
+	if subsetFile != "" {
+		runes, err := readRequiredChars(subsetFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithSubset(runes))
+	}
+	if fullThreshold > 0 {
+		opts = append(opts, WithFullWidthThreshold(fullThreshold))
+	} else if halfThreshold != 0.5 {
+		opts = append(opts, WithFullWidthThreshold(halfRatioThreshold(size, halfThreshold)))
+	}
+	if threshold != bitimg.DefaultThreshold {
+		opts = append(opts, WithThreshold(uint8(threshold)))
+	}
+	if blankAsSpace {
+		opts = append(opts, WithBlankAsSpace())
+	}
+	if checksum {
+		opts = append(opts, WithChecksum())
+	}
+	if nonNegDescent {
+		opts = append(opts, WithNonNegativeDescent())
+	}
+	if pow2Width {
+		opts = append(opts, WithPow2Width())
+	}
+	if bold {
+		opts = append(opts, WithBold())
+	}
+	if strokeWidth > 0 {
+		opts = append(opts, WithStrokeWidth(strokeWidth))
+	}
+	if italic > 0 {
+		opts = append(opts, WithItalic(italic))
+	}
+	if charset != "ISO10646-1" {
+		registry, encoding, err := parseCharset(charset)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithCharset(registry, encoding))
+	}
+	if gzipOut {
+		opts = append(opts, WithGzip())
+	}
+	if invert {
+		opts = append(opts, WithInvert())
+	}
+	if mono {
+		opts = append(opts, WithMono())
+	}
+	if skipBlank {
+		opts = append(opts, WithSkipBlank())
+	}
+	if unmapped {
+		opts = append(opts, WithIncludeUnmapped())
+	}
+	if tightBBX {
+		opts = append(opts, WithTightBBX())
+	}
+	if rowAlign > 1 {
+		opts = append(opts, WithRowAlign(rowAlign))
+	}
+	if exactSWidth {
+		opts = append(opts, WithExactSWidth())
+	}
+	if maxChars > 0 {
+		opts = append(opts, WithMaxChars(maxChars))
+	}
+	if rotate != 0 {
+		opts = append(opts, WithRotation(rotate))
+	}
+	if glyphBBX {
+		opts = append(opts, WithGlyphBBX())
+	}
+	if rateLimit > 0 {
+		opts = append(opts, WithRateLimit(rateLimit))
+	}
+	if noKerning {
+		opts = append(opts, WithSnapAdvance())
+	}
+	if progressBar {
+		if isTerminal(os.Stderr) {
+			opts = append(opts, WithProgress(newProgressBar(os.Stderr, 40)))
+		} else {
+			opts = append(opts, WithProgress(newProgressLogger()))
+		}
+	} else if verbose {
+		opts = append(opts, WithProgress(newProgressCounter(os.Stderr)))
+	}
+	var stats glyphTimingStats
+	if timing {
+		opts = append(opts, WithGlyphTiming(stats.record))
+		defer stats.log()
+	}
 
-	cvt, err := newBDFConverter(inName, size)
+	// The grayscale previews and the debug PNGs are only for the main
+	// conversion: the companion conversions below must not overwrite them.
+	cvtOpts := opts
+	if grayDir != "" {
+		if err := os.MkdirAll(grayDir, 0o755); err != nil {
+			return err
+		}
+		cvtOpts = append(slices.Clip(cvtOpts), WithGrayscaleIntermediate(grayDir))
+	}
+	if debugDir != "" {
+		if err := os.MkdirAll(debugDir, 0o755); err != nil {
+			return err
+		}
+		cvtOpts = append(slices.Clip(cvtOpts), WithDebugGlyphs(debugDir))
+	}
+
+	cvt, err := NewBDFConverter(inName, size, cvtOpts...)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(cvt, &err)
+	if compareMetrics != "" {
+		return runCompareMetrics(cvt, compareMetrics, size, opts...)
+	}
+	if dryRun {
+		return runDryRun(cvt)
+	}
+	if warm > 0 {
+		if err := cvt.Warm(warm); err != nil {
+			return err
+		}
+	}
+	if exportBundle != "" {
+		if err := cvt.ExportBundle(exportBundle); err != nil {
+			return err
+		}
+		if outName == "" && outputDir == "" {
+			return nil
+		}
+	}
+	if outputDir != "" {
+		outName = filepath.Join(outputDir, cvt.defaultOutName())
+	}
+	// When -out points to an existing directory, name the file automatically.
+	if fi, err := os.Stat(outName); err == nil && fi.IsDir() {
+		outName = filepath.Join(outName, cvt.defaultOutName())
+	}
+	if gzipOut && format == "bdf" && !isGzipName(outName) {
+		outName += ".gz"
+	}
+	var dm *densityMap
+	if heatmap != "" {
+		dm = newDensityMap(cvt.fullWidth, cvt.height)
+		cvt.observe = dm.add
+	}
+	switch format {
+	case "bdf":
+		if splitByBlock {
+			err = cvt.ConvertByBlock(outName)
+		} else {
+			err = cvt.Convert(outName)
+		}
+	case "psf":
+		err = cvt.ConvertPSF(outName)
+	case "pcf":
+		err = cvt.ConvertPCF(outName)
+	default:
+		err = fmt.Errorf("unknown -format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	if verbose {
+		half, full := cvt.GlyphWidthStats()
+		fmt.Fprintf(os.Stderr, "half width: %d glyphs, full width: %d glyphs\n", half, full)
+	}
+	if dm != nil {
+		if err := dm.writePNG(heatmap); err != nil {
+			return err
+		}
+	}
+	if verify {
+		if err := cvt.Verify(outName, verifyTol); err != nil {
+			return err
+		}
+	}
+	if memStats {
+		ms := cvt.MemoryUsage()
+		slog.Info("memory usage",
+			"font", ms.FontBytes,
+			"face", ms.FaceBytes,
+			"buffer", ms.BufferBytes,
+			"current", ms.CurrentBytes,
+			"peak", ms.PeakBytes)
+	}
+	if exportGIDMap != "" {
+		if err := cvt.ExportGIDMap(exportGIDMap); err != nil {
+			return err
+		}
+	}
+	if emitUnhinted {
+		err := runUnhinted(cvt, inName, suffixedOutName(outName, "-unhinted"), hintThreshold, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	if kernMap != "" {
+		return runKern(cvt, kernMap, suffixedOutName(outName, "-kern"))
+	}
+	return nil
+}
+
+// runDryRun prints the number of glyphs which would be converted, and their
+// average width.
+func runDryRun(cvt *BDFConverter) error {
+	glyphCount, half, full, err := cvt.Count()
 	if err != nil {
 		return err
 	}
-	defer cvt.Close()
-	return cvt.Convert(outName)
+	averageWidth := 0.0
+	if glyphCount > 0 {
+		widthSum := half*cvt.halfWidth + full*cvt.fullWidth
+		averageWidth = float64(widthSum*cvt.pixelScale) / float64(glyphCount)
+	}
+	fmt.Printf("glyphs: %d (half width: %d, full width: %d)\n", glyphCount, half, full)
+	fmt.Printf("average width: %.1f px\n", averageWidth)
+	return nil
 }
 
 func main() {
-	err := Run(context.Background(), os.Args[1:])
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err := Run(ctx, os.Args[1:])
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("failed", "err", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,724 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+//go:generate go test -run TestRunGolden -update-golden .
+
+var updateGolden = flag.Bool("update-golden", false, "update testdata/goregular-16.bdf of TestRunGolden")
+
+// convertGoRegular converts Go Regular at the size with opts, and parses the
+// result.
+func convertGoRegular(t testing.TB, size int, opts ...Option) *bdf.Font {
+	t.Helper()
+	return convertFont(t, goregular.TTF, size, opts...)
+}
+
+// convertFont converts the font data at the size with opts, and parses the
+// result.
+func convertFont(t testing.TB, data []byte, size int, opts ...Option) *bdf.Font {
+	t.Helper()
+	cvt, err := NewBDFConverterFromBytes(data, size, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	var buf bytes.Buffer
+	if err := cvt.ConvertWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	f, err := bdf.Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// findGlyph returns the glyph of the ENCODING, or fails the test.
+func findGlyph(t testing.TB, f *bdf.Font, encoding int) *bdf.Glyph {
+	t.Helper()
+	for _, g := range f.Glyphs {
+		if g.Encoding == encoding {
+			return g
+		}
+	}
+	t.Fatalf("no glyph of ENCODING %d", encoding)
+	return nil
+}
+
+// intProperty returns the integer value of the property, or fails the test.
+func intProperty(t testing.TB, f *bdf.Font, name string) int {
+	t.Helper()
+	v, err := strconv.Atoi(f.Properties[name])
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return v
+}
+
+func TestFontAscentDescent(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"non-negative descent", []Option{WithNonNegativeDescent()}},
+		{"pixel doubling", []Option{WithNonNegativeDescent(), WithPixelDoubling(2)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := convertGoRegular(t, 16, tc.opts...)
+			ascent, descent := intProperty(t, f, "FONT_ASCENT"), intProperty(t, f, "FONT_DESCENT")
+			if box := f.BoundingBox; ascent != box[1]+box[3] || descent != -box[3] {
+				t.Errorf("FONT_ASCENT %d and FONT_DESCENT %d disagree with FONTBOUNDINGBOX %v", ascent, descent, box)
+			}
+			for _, g := range f.Glyphs {
+				if g.BBX[3] < -descent {
+					t.Fatalf("%s: BBX %v is below FONT_DESCENT %d", g.Name, g.BBX, descent)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultChar(t *testing.T) {
+	f := convertGoRegular(t, 16, WithRuneFilter(inRuneRanges([]runeRange{{' ', 'Z'}})))
+	if got := intProperty(t, f, "DEFAULT_CHAR"); got != ' ' {
+		t.Errorf("DEFAULT_CHAR = %d; want %d", got, ' ')
+	}
+	f = convertGoRegular(t, 16, WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}})))
+	if v, ok := f.Properties["DEFAULT_CHAR"]; ok {
+		t.Errorf("DEFAULT_CHAR = %s; want it omitted without U+0020", v)
+	}
+}
+
+func TestOptionComposition(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		opts            []Option
+		ascent, descent int
+		family          string
+		resolution      int
+	}{
+		{"default", nil, 16, 4, "Go", 72},
+		{"all", []Option{WithDPI(96), WithHinting(font.HintingNone), WithAscent(14), WithDescent(3), WithFamilyName("Test")}, 14, 3, "Test", 96},
+		{"last wins", []Option{WithDPI(75), WithFamilyName("A"), WithDPI(96), WithFamilyName("B")}, 16, 4, "B", 96},
+		{"negative ignored", []Option{WithAscent(-1), WithDescent(-1)}, 16, 4, "Go", 72},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cvt.Close()
+			if cvt.Ascent() != tc.ascent || cvt.Descent() != tc.descent {
+				t.Errorf("ascent %d, descent %d; want %d, %d", cvt.Ascent(), cvt.Descent(), tc.ascent, tc.descent)
+			}
+			if got := cvt.FamilyName(); got != tc.family {
+				t.Errorf("FamilyName() = %q; want %q", got, tc.family)
+			}
+			var buf bytes.Buffer
+			if err := cvt.ConvertWriter(&buf); err != nil {
+				t.Fatal(err)
+			}
+			f, err := bdf.Parse(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Size[1] != tc.resolution || f.Size[2] != tc.resolution {
+				t.Errorf("SIZE %v; want the resolution %d", f.Size, tc.resolution)
+			}
+			if want := "-FreeType-" + tc.family + "-"; !strings.HasPrefix(f.Name, want) {
+				t.Errorf("FONT %s; want the prefix %s", f.Name, want)
+			}
+		})
+	}
+}
+
+func benchmarkConvert(b *testing.B, warm bool) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if warm {
+			if err := cvt.Warm(0); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StartTimer()
+		if err := cvt.ConvertWriter(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+		b.StopTimer()
+		cvt.Close()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkConvertCold(b *testing.B) { benchmarkConvert(b, false) }
+
+func BenchmarkConvertWarm(b *testing.B) { benchmarkConvert(b, true) }
+
+// benchGlyphs are the reference glyphs of BenchmarkRenderGlyph.
+var benchGlyphs = []rune("AQag@&%")
+
+// BenchmarkRenderGlyph renders the reference glyphs at the sizes and the
+// thresholds. The set pixels per glyph show how the threshold affects the
+// bitmaps.
+func BenchmarkRenderGlyph(b *testing.B) {
+	for _, size := range []int{8, 16, 32} {
+		for _, threshold := range []uint8{63, bitimg.DefaultThreshold, 191} {
+			b.Run(fmt.Sprintf("size=%d/threshold=%d", size, threshold), func(b *testing.B) {
+				cvt, err := NewBDFConverterFromBytes(goregular.TTF, size, WithThreshold(threshold))
+				if err != nil {
+					b.Fatal(err)
+				}
+				defer cvt.Close()
+				img := cvt.newCell(cvt.fullWidth)
+				setBits := 0
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					for _, r := range benchGlyphs {
+						cvt.renderGlyph(img, r)
+						setBits += img.CountSetBits()
+					}
+				}
+				b.ReportMetric(float64(setBits)/float64(b.N*len(benchGlyphs)), "setbits/glyph")
+			})
+		}
+	}
+}
+
+func BenchmarkWriteBody(b *testing.B) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer cvt.Close()
+	total, _ := cvt.countGlyphs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cvt.writeBody(io.Discard, total, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// writeGoRegular writes Go Regular to a temporary file, and returns its name.
+func writeGoRegular(t testing.TB) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "goregular.ttf")
+	if err := os.WriteFile(name, goregular.TTF, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestRunPointSize(t *testing.T) {
+	font := writeGoRegular(t)
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(config, []byte("size = 20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.bdf")
+	err := Run(context.Background(), []string{"-config", config, "-point-size", "12", "-out", out, font})
+	if err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Errorf("-size of -config with -point-size: got %v; want a conflict", err)
+	}
+
+	// 12 points at 75 DPI are 12.5 pixels, which are rounded to 12.
+	if err := Run(context.Background(), []string{"-quiet", "-point-size", "12", "-dpi", "75", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b, err := bdf.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [3]int{12, 75, 75}; b.Size != want {
+		t.Errorf("SIZE %v; want %v", b.Size, want)
+	}
+	if got := b.BoundingBox[1]; got != 12 {
+		t.Errorf("FONTBOUNDINGBOX height %d; want 12", got)
+	}
+}
+
+func TestRunGzipFormat(t *testing.T) {
+	font := writeGoRegular(t)
+	for _, format := range []string{"psf", "pcf"} {
+		out := filepath.Join(t.TempDir(), "out."+format)
+		err := Run(context.Background(), []string{"-gz", "-format", format, "-out", out, font})
+		if err == nil {
+			t.Errorf("-gz -format %s: got no error", format)
+		}
+	}
+}
+
+func TestRunFontNameConflict(t *testing.T) {
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	err := Run(context.Background(), []string{"-family-name", "A", "-font-name", "B", "-out", out, font})
+	if err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Errorf("-family-name with -font-name: got %v; want a conflict", err)
+	}
+}
+
+func TestRunFontName(t *testing.T) {
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	if err := Run(context.Background(), []string{"-quiet", "-font-name", "Alias", "-range", "U+0041-U+0041", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := parseBDFFile(t, out).Name, "-FreeType-Alias-Medium-R-"; !strings.HasPrefix(got, want) {
+		t.Errorf("FONT %s; want the prefix %s", got, want)
+	}
+}
+
+func TestRunGlyphPrefix(t *testing.T) {
+	font := writeGoRegular(t)
+	for _, tc := range []struct {
+		args []string
+		want map[int]string
+	}{
+		{nil, map[int]string{'A': "U+0041", 0xC1: "U+00C1"}},
+		{[]string{"-glyph-prefix", "uni"}, map[int]string{'A': "uni0041", 0xC1: "uni00C1"}},
+		// The names of the post table take precedence over the prefix.
+		{[]string{"-glyph-prefix", "uni", "-post-names"}, map[int]string{'A': "A", 0xC1: "Aacute"}},
+	} {
+		out := filepath.Join(t.TempDir(), "out.bdf")
+		args := append([]string{"-quiet", "-range", "U+0041-U+0041", "-range", "U+00C1-U+00C1", "-out", out}, tc.args...)
+		if err := Run(context.Background(), append(args, font)); err != nil {
+			t.Fatal(err)
+		}
+		f := parseBDFFile(t, out)
+		for enc, want := range tc.want {
+			if got := findGlyph(t, f, enc).Name; got != want {
+				t.Errorf("%v: STARTCHAR %s of ENCODING %d; want %s", tc.args, got, enc, want)
+			}
+		}
+	}
+}
+
+func TestGlyphNameWithoutPostName(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithGlyphPrefix("uni"), WithPostNames())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	// Go Regular has no glyph of U+4E00, so it has no name in the post table.
+	if got, want := cvt.glyphName(0x4E00), "uni4E00"; got != want {
+		t.Errorf("glyphName(U+4E00) = %s; want %s", got, want)
+	}
+}
+
+// supplementaryFont returns a subset of Go Regular with U+0041 and U+00C1,
+// whose cmap maps U+1F600 instead of U+00C1 to the glyph of U+00C1.
+func supplementaryFont(t testing.TB) []byte {
+	t.Helper()
+	raw, err := sfnttab.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := raw.Subset([]rune{'A', 0xC1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw, err = sfnttab.Parse(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, tab := range raw.Tables {
+		if tab.Tag != "cmap" {
+			continue
+		}
+		// The last group of the subtable of format 12, after the header of
+		// cmap of 12 bytes and that of the subtable of 16 bytes.
+		sub := data[tab.Offset+12 : tab.Offset+tab.Length]
+		last := sub[16+(binary.BigEndian.Uint32(sub[12:])-1)*12:]
+		if start := binary.BigEndian.Uint32(last); start != 0xC1 {
+			t.Fatalf("last group of cmap starts at U+%04X; want U+00C1", start)
+		}
+		binary.BigEndian.PutUint32(last, 0x1F600)
+		binary.BigEndian.PutUint32(last[4:], 0x1F600)
+		return data
+	}
+	t.Fatal("no cmap table")
+	return nil
+}
+
+func TestSupplementaryPlane(t *testing.T) {
+	f := convertFont(t, supplementaryFont(t), 16)
+	if f.Chars != 2 {
+		t.Errorf("CHARS %d; want 2", f.Chars)
+	}
+	g := findGlyph(t, f, 0x1F600)
+	if g.Name != "U+1F600" {
+		t.Errorf("STARTCHAR %s; want U+1F600", g.Name)
+	}
+	want := findGlyph(t, convertGoRegular(t, 16), 0xC1)
+	if !slices.Equal(g.Bitmap, want.Bitmap) {
+		t.Errorf("BITMAP of U+1F600 %v; want %v of U+00C1", g.Bitmap, want.Bitmap)
+	}
+	if last := f.Glyphs[len(f.Glyphs)-1]; last != g {
+		t.Errorf("last glyph %s; want U+1F600 after U+0041", last.Name)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := convertGoRegular(t, 16)
+	if !f.HasEndFont {
+		t.Errorf("no ENDFONT")
+	}
+	if f.Chars != len(f.Glyphs) {
+		t.Errorf("CHARS %d; want the number of glyphs %d", f.Chars, len(f.Glyphs))
+	}
+	g := findGlyph(t, f, 'A')
+	if g.Name != "U+0041" {
+		t.Errorf("STARTCHAR %s; want U+0041", g.Name)
+	}
+	if want := [2]int{16, 0}; g.DWidth != want {
+		t.Errorf("DWIDTH %v; want %v", g.DWidth, want)
+	}
+	if want := [4]int{16, 16, 0, -4}; g.BBX != want {
+		t.Errorf("BBX %v; want %v", g.BBX, want)
+	}
+	for _, g := range f.Glyphs {
+		if g.Encoding >= 0 {
+			if want := fmt.Sprintf("U+%04X", g.Encoding); g.Name != want {
+				t.Errorf("STARTCHAR %s of ENCODING %d; want %s", g.Name, g.Encoding, want)
+			}
+		}
+		if len(g.Bitmap) != g.BBX[1] {
+			t.Errorf("%s: %d rows of BITMAP; want %d", g.Name, len(g.Bitmap), g.BBX[1])
+		}
+		digits := 2 * ((g.BBX[0] + 7) / 8)
+		for i, row := range g.Bitmap {
+			if len(row) != digits {
+				t.Errorf("%s: row %d of BITMAP has %d hex digits; want %d", g.Name, i, len(row), digits)
+				break
+			}
+		}
+	}
+}
+
+// goldenGlyphs are the glyphs which TestRunGolden compares with the golden
+// file. Only U+0041 is checked: U+4E2D is skipped, as Go Regular has no CJK
+// glyphs.
+var goldenGlyphs = []rune{'A'}
+
+func TestRunGolden(t *testing.T) {
+	const golden = "testdata/goregular-16.bdf"
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	if err := Run(context.Background(), []string{"-quiet", "-size", "16", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	if *updateGolden {
+		// Only the glyphs to check are kept in the golden file.
+		args := []string{"-quiet", "-size", "16", "-out", golden}
+		for _, r := range goldenGlyphs {
+			args = append(args, "-range", fmt.Sprintf("U+%04X-U+%04X", r, r))
+		}
+		if err := Run(context.Background(), append(args, font)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, want := parseBDFFile(t, out), parseBDFFile(t, golden)
+	for _, r := range goldenGlyphs {
+		g, w := *findGlyph(t, got, int(r)), *findGlyph(t, want, int(r))
+		g.Line, w.Line = 0, 0
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("U+%04X: got %+v; want %+v", r, g, w)
+		}
+	}
+	for _, g := range got.Glyphs {
+		if g.Encoding == 0x4e2d {
+			t.Errorf("U+4E2D is written, though Go Regular has no glyph of it")
+		}
+	}
+}
+
+// parseBDFFile parses the BDF file name.
+func parseBDFFile(t testing.TB, name string) *bdf.Font {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b, err := bdf.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestFontNameTmpl(t *testing.T) {
+	for _, tc := range []struct {
+		tmpl, want string
+	}{
+		{"{family}", "Go"},
+		{"{postscript_name}", "GoRegular"},
+		{"{family} {size}px", "Go 16px"},
+		{"X{postscript_name}{size}", "XGoRegular16"},
+	} {
+		cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithFontNameTmpl(tc.tmpl))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cvt.fontName(); got != tc.want {
+			t.Errorf("fontName() of %q = %q; want %q", tc.tmpl, got, tc.want)
+		}
+		if got, want := cvt.fontLine(0), "-FreeType-"+tc.want+"-"; !strings.HasPrefix(got, want) {
+			t.Errorf("FONT %s of %q; want the prefix %s", got, tc.tmpl, want)
+		}
+		cvt.Close()
+	}
+}
+
+func TestFamilyName(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithFamilyName("My Font"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	if got := cvt.FamilyName(); got != "My Font" {
+		t.Errorf("FamilyName() = %q; want the overridden name, not the one of the name table", got)
+	}
+	if got, want := cvt.defaultOutName(), "My_Font-16px.bdf"; got != want {
+		t.Errorf("defaultOutName() = %q; want %q", got, want)
+	}
+
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	if err := Run(context.Background(), []string{"-quiet", "-family-name", "My Font", "-range", "U+0041-U+0041", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+	f := parseBDFFile(t, out)
+	if want := "-FreeType-My Font-"; !strings.HasPrefix(f.Name, want) {
+		t.Errorf("FONT %s; want the prefix %s", f.Name, want)
+	}
+	for name, v := range f.Properties {
+		if strings.Contains(v, "Go") {
+			t.Errorf("property %s %s has the family name of the name table", name, v)
+		}
+	}
+}
+
+func TestIsFullWidth(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		adv  int
+		want bool
+	}{
+		{"default below", nil, 8, false},
+		{"default at threshold", nil, 9, true},
+		{"configured below", []Option{WithFullWidthThreshold(10)}, 9, false},
+		{"configured at threshold", []Option{WithFullWidthThreshold(10)}, 10, true},
+		{"configured above", []Option{WithFullWidthThreshold(10)}, 11, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cvt.Close()
+			if got := cvt.isFullWidth(fixed.I(tc.adv)); got != tc.want {
+				t.Errorf("isFullWidth(%d) = %t; want %t", tc.adv, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMono(t *testing.T) {
+	const size = 16
+	f := convertGoRegular(t, size, WithMono())
+	for _, g := range f.Glyphs {
+		if g.DWidth[0] < size {
+			t.Fatalf("%s: DWIDTH %v is less than the size %d", g.Name, g.DWidth, size)
+		}
+	}
+	if got := f.BoundingBox[0]; got != size {
+		t.Errorf("FONTBOUNDINGBOX width %d; want %d", got, size)
+	}
+	if fields := strings.Split(f.Name, "-"); fields[11] != "M" || fields[12] != "160" {
+		t.Errorf("FONT %s; want the spacing M and the average width 160", f.Name)
+	}
+}
+
+func TestHalfThreshold(t *testing.T) {
+	if got := halfRatioThreshold(16, 0.5); got != 16/2+1 {
+		t.Errorf("halfRatioThreshold(16, 0.5) = %d; want the default %d", got, 16/2+1)
+	}
+	// A CJK glyph of the advance 15 at the size 16 is full width with 0.875.
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithFullWidthThreshold(halfRatioThreshold(16, 0.875)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	if !cvt.isFullWidth(fixed.I(15)) {
+		t.Errorf("advance 15 is half width; want full width")
+	}
+	if cvt.isFullWidth(fixed.I(14)) {
+		t.Errorf("advance 14 is full width; want half width")
+	}
+
+	// 'M' of Go Regular has the advance 13 at the size 16.
+	font := writeGoRegular(t)
+	for _, tc := range []struct {
+		ratio  string
+		dwidth int
+	}{
+		{"0.5", 16},
+		{"0.875", 8},
+	} {
+		out := filepath.Join(t.TempDir(), "out.bdf")
+		if err := Run(context.Background(), []string{"-quiet", "-half-threshold", tc.ratio, "-range", "U+004D-U+004D", "-out", out, font}); err != nil {
+			t.Fatal(err)
+		}
+		if got := findGlyph(t, parseBDFFile(t, out), 'M').DWidth[0]; got != tc.dwidth {
+			t.Errorf("-half-threshold %s: DWIDTH of M %d; want %d", tc.ratio, got, tc.dwidth)
+		}
+	}
+}
+
+func TestExactSWidth(t *testing.T) {
+	// The advances of unhinted glyphs have fractional pixels.
+	unhinted := WithHinting(font.HintingNone)
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, unhinted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	// 7.5 pixels at 16 pixels are 468.75 of 1000, while the DWIDTH 8 is 500.
+	adv := fixed.I(7) + 32
+	if got := cvt.exactScalableWidth(adv); got != 469 {
+		t.Errorf("exactScalableWidth(%v) = %d; want 469", adv, got)
+	}
+	if got := cvt.scalableWidth(roundAdv(adv, RoundNearest)); got != 500 {
+		t.Errorf("scalableWidth(8) = %d; want 500", got)
+	}
+
+	only := WithRuneFilter(inRuneRanges([]runeRange{{'a', 'z'}}))
+	plain, exact := convertGoRegular(t, 16, only, unhinted), convertGoRegular(t, 16, only, unhinted, WithExactSWidth())
+	fractional := 0
+	for i, g := range exact.Glyphs {
+		adv, _ := cvt.face.GlyphAdvance(rune(g.Encoding))
+		if adv%64 != 0 {
+			fractional++
+		}
+		if want := int(math.Round(float64(adv) / 64 / 16 * 1000)); g.SWidth[0] != want {
+			t.Errorf("%s: SWIDTH %d of the advance %v; want %d", g.Name, g.SWidth[0], adv, want)
+		}
+		if p := plain.Glyphs[i]; g.DWidth != p.DWidth {
+			t.Errorf("%s: DWIDTH %v; want %v", g.Name, g.DWidth, p.DWidth)
+		}
+	}
+	if fractional == 0 {
+		t.Errorf("no glyphs of fractional advances are tested")
+	}
+}
+
+func TestBitmapHexDigits(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		opts  []Option
+		align int
+	}{
+		{"default", nil, 1},
+		{"tight BBX", []Option{WithTightBBX()}, 1},
+		{"row align", []Option{WithRowAlign(4)}, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := convertGoRegular(t, 16, tc.opts...)
+			small := false
+			for _, g := range f.Glyphs {
+				xn := (g.BBX[0] + 7) / 8
+				want := 2 * (xn + (tc.align-xn%tc.align)%tc.align)
+				for _, row := range g.Bitmap {
+					if len(row) != want {
+						t.Fatalf("%s: BITMAP row %s of BBX %v; want %d hex digits", g.Name, row, g.BBX, want)
+					}
+					small = small || row[0] == '0' && row[1] != '0'
+				}
+			}
+			// e.g. "0400" of U+0041.
+			if !small {
+				t.Errorf("no rows start with a byte less than 0x10")
+			}
+		})
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	for _, tc := range []struct {
+		s, want string
+	}{
+		{"Go", "Go"},
+		{"Noto Sans CJK JP", "Noto_Sans_CJK_JP"},
+		{`A/B\C:D*E?F"G<H>I|J`, "ABCDEFGHIJ"},
+		{"Mono: Bold/Italic?", "Mono_BoldItalic"},
+	} {
+		if got := sanitizeFileName(tc.s); got != tc.want {
+			t.Errorf("sanitizeFileName(%q) = %q; want %q", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestRunOutputDir(t *testing.T) {
+	font := writeGoRegular(t)
+	dir := t.TempDir()
+	if err := Run(context.Background(), []string{"-quiet", "-output-dir", dir, "-family-name", `My Font: "Test"/1`, "-range", "U+0041-U+0041", font}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "My_Font_Test1-16px.bdf")); err != nil {
+		t.Error(err)
+	}
+	err := Run(context.Background(), []string{"-output-dir", dir, "-out", filepath.Join(dir, "out.bdf"), font})
+	if err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Errorf("-out with -output-dir: got %v; want a conflict", err)
+	}
+}
+
+func TestRunPow2WidthCheck(t *testing.T) {
+	font := writeGoRegular(t)
+	for _, args := range [][]string{
+		{"-size", "12", "-pow2-width"},
+		{"-size", "12", "-pow2-width", "-italic", "0.3"},
+	} {
+		out := filepath.Join(t.TempDir(), "out.bdf")
+		if err := Run(context.Background(), append(append([]string{"-quiet", "-out", out}, args...), font)); err != nil {
+			t.Fatal(err)
+		}
+		if err := runCheck([]string{out}); err != nil {
+			t.Errorf("check of %v: %v", args, err)
+		}
+	}
+}
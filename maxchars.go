@@ -0,0 +1,57 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// GlyphRanker returns the rank of the rune for WithMaxChars: runes with lower
+// ranks are kept first.
+type GlyphRanker func(r rune) int
+
+// rankByCodePoint is the default GlyphRanker, which keeps the lowest code
+// points: the simplest proxy for usage without a frequency table.
+func rankByCodePoint(r rune) int {
+	return int(r)
+}
+
+// WithMaxChars limits the glyphs to convert to the n runes with the lowest
+// ranks of WithGlyphRanker, by code point by default. It applies after the
+// other filters, and the glyphs are still written in code point order.
+func WithMaxChars(n int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.maxChars = n
+	}
+}
+
+// WithGlyphRanker sets the ranks of the runes for WithMaxChars.
+func WithGlyphRanker(rank GlyphRanker) Option {
+	return func(cvt *BDFConverter) {
+		cvt.ranker = rank
+	}
+}
+
+// limitChars restricts the filter to the runes of WithMaxChars. It must be
+// called after the face is opened.
+func (cvt *BDFConverter) limitChars() {
+	var runes []rune
+	for r := range cvt.glyphs() {
+		runes = append(runes, r)
+	}
+	if len(runes) <= cvt.maxChars {
+		return
+	}
+	rank := cvt.ranker
+	if rank == nil {
+		rank = rankByCodePoint
+	}
+	// runes are in code point order, so the stable sort breaks ties by it.
+	slices.SortStableFunc(runes, func(a, b rune) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+	keep := make(map[rune]bool, cvt.maxChars)
+	for _, r := range runes[:cvt.maxChars] {
+		keep[r] = true
+	}
+	WithRuneFilter(func(r rune) bool { return keep[r] })(cvt)
+}
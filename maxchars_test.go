@@ -0,0 +1,40 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMaxChars(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		n    int
+		want []int // the ENCODINGs, or nil not to check them
+	}{
+		{"lowest code points", nil, 50, nil},
+		{"filtered", []Option{WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}}))}, 3, []int{'A', 'B', 'C'}},
+		{"ranked", []Option{WithRuneFilter(inRuneRanges([]runeRange{{'A', 'Z'}})), WithGlyphRanker(func(r rune) int { return -int(r) })}, 3, []int{'X', 'Y', 'Z'}},
+		{"more than the glyphs", []Option{WithRuneFilter(inRuneRanges([]runeRange{{'A', 'E'}}))}, 10, []int{'A', 'B', 'C', 'D', 'E'}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := convertGoRegular(t, 16, append(tc.opts, WithMaxChars(tc.n))...)
+			if f.Chars != len(f.Glyphs) {
+				t.Errorf("CHARS %d; want the number of glyphs %d", f.Chars, len(f.Glyphs))
+			}
+			if tc.want == nil {
+				if f.Chars != tc.n {
+					t.Errorf("CHARS %d; want %d", f.Chars, tc.n)
+				}
+				return
+			}
+			var got []int
+			for _, g := range f.Glyphs {
+				got = append(got, g.Encoding)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("ENCODINGs %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
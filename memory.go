@@ -0,0 +1,55 @@
+package main
+
+import "runtime"
+
+// MemoryStats is the memory used by a BDFConverter, estimated from snapshots
+// of runtime.MemStats.
+type MemoryStats struct {
+	// FontBytes is the memory used to parse the font.
+	FontBytes uint64
+	// FaceBytes is the memory used to create the font face.
+	FaceBytes uint64
+	// BufferBytes is the size of the bitmap buffers of the conversion.
+	BufferBytes uint64
+
+	// CurrentBytes is the heap grown since the construction of the converter.
+	CurrentBytes uint64
+	// PeakBytes is the largest CurrentBytes observed during the conversion.
+	PeakBytes uint64
+}
+
+// memTracker takes snapshots of the heap relative to a baseline.
+type memTracker struct {
+	baseline uint64
+	stats    MemoryStats
+}
+
+// memSampleInterval is the number of glyphs between samples of the heap.
+const memSampleInterval = 256
+
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+func (mt *memTracker) start() {
+	mt.baseline = heapAlloc()
+}
+
+// sample returns the heap grown since the last call of start, and records the
+// peak.
+func (mt *memTracker) sample() uint64 {
+	var delta uint64
+	if now := heapAlloc(); now > mt.baseline {
+		delta = now - mt.baseline
+	}
+	mt.stats.PeakBytes = max(mt.stats.PeakBytes, delta)
+	return delta
+}
+
+// MemoryUsage returns the current and peak memory used by the converter.
+func (cvt *BDFConverter) MemoryUsage() MemoryStats {
+	cvt.mem.stats.CurrentBytes = cvt.mem.sample()
+	return cvt.mem.stats
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// BDFMetrics is the set of metrics of a converted font.
+type BDFMetrics struct {
+	Family       string
+	Size         int
+	HalfWidth    int
+	FullWidth    int
+	Height       int
+	Ascent       int
+	Descent      int
+	Chars        int
+	AverageWidth int
+}
+
+// Metrics returns the metrics of the font to be converted.
+func (cvt *BDFConverter) Metrics() BDFMetrics {
+	glyphCount, widthSum := cvt.countGlyphs()
+	averageWidth := 0
+	if glyphCount > 0 {
+		averageWidth = widthSum * 10 / glyphCount
+	}
+	return BDFMetrics{
+		Family:       cvt.name,
+		Size:         cvt.size,
+		HalfWidth:    cvt.halfWidth,
+		FullWidth:    cvt.fullWidth,
+		Height:       cvt.height,
+		Ascent:       cvt.ascent,
+		Descent:      cvt.descent,
+		Chars:        glyphCount,
+		AverageWidth: averageWidth,
+	}
+}
+
+// MetricsChange is a field of BDFMetrics which has different values.
+type MetricsChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// MetricsDiff lists the changed fields of BDFMetrics.
+type MetricsDiff []MetricsChange
+
+// CompareFontMetrics compares the metrics of two fonts, a is the old and b is
+// the new one.
+func CompareFontMetrics(a, b *BDFConverter) MetricsDiff {
+	va := reflect.ValueOf(a.Metrics())
+	vb := reflect.ValueOf(b.Metrics())
+	var diff MetricsDiff
+	for i := 0; i < va.NumField(); i++ {
+		oldv, newv := va.Field(i).Interface(), vb.Field(i).Interface()
+		if oldv != newv {
+			diff = append(diff, MetricsChange{
+				Field: va.Type().Field(i).Name,
+				Old:   oldv,
+				New:   newv,
+			})
+		}
+	}
+	return diff
+}
+
+// runCompareMetrics compares the metrics of cvt with the font otherName, and
+// prints the changes.
+func runCompareMetrics(cvt *BDFConverter, otherName string, size int, opts ...Option) (err error) {
+	other, err := NewBDFConverter(otherName, size, opts...)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(other, &err)
+	diff := CompareFontMetrics(cvt, other)
+	for _, c := range diff {
+		fmt.Printf("%s: %v -> %v\n", c.Field, c.Old, c.New)
+	}
+	if len(diff) > 0 {
+		return errors.New("metrics differ")
+	}
+	return nil
+}
+
+// fontMetrics is the output of "metrics" subcommand. The advance widths are
+// in pixels.
+type fontMetrics struct {
+	FamilyName     string  `json:"familyName"`
+	GlyphCount     int     `json:"glyphCount"`
+	HalfWidthCount int     `json:"halfWidthCount"`
+	FullWidthCount int     `json:"fullWidthCount"`
+	Ascent         int     `json:"ascent"`
+	Descent        int     `json:"descent"`
+	HalfWidth      int     `json:"halfWidth"`
+	FullWidth      int     `json:"fullWidth"`
+	MinAdvance     int     `json:"minAdvance"`
+	MaxAdvance     int     `json:"maxAdvance"`
+	AverageAdvance float64 `json:"averageAdvance"`
+}
+
+// fontMetrics enumerates the glyphs to convert and summarizes their advance
+// widths.
+func (cvt *BDFConverter) fontMetrics() fontMetrics {
+	m := fontMetrics{
+		FamilyName: cvt.name,
+		Ascent:     cvt.ascent,
+		Descent:    cvt.descent,
+		HalfWidth:  cvt.halfWidth,
+		FullWidth:  cvt.fullWidth,
+	}
+	sum := 0
+	for _, adv := range cvt.glyphs() {
+		w := roundAdv(adv, cvt.rounding)
+		if m.GlyphCount == 0 || w < m.MinAdvance {
+			m.MinAdvance = w
+		}
+		m.MaxAdvance = max(m.MaxAdvance, w)
+		sum += w
+		m.GlyphCount++
+		if cvt.isFullWidth(adv) {
+			m.FullWidthCount++
+		} else {
+			m.HalfWidthCount++
+		}
+	}
+	if m.GlyphCount > 0 {
+		m.AverageAdvance = float64(sum) / float64(m.GlyphCount)
+	}
+	return m
+}
+
+// runMetrics runs "metrics" subcommand, which prints the metrics of a font
+// as JSON without converting it.
+func runMetrics(args []string) (err error) {
+	var size int
+	fs := flag.NewFlagSet("metrics", flag.ExitOnError)
+	fs.IntVar(&size, "size", 16, `font size`)
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errors.New("an argument is required: the OTF/TTF file to inspect")
+	}
+	cvt, err := NewBDFConverter(fs.Arg(0), size)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(cvt, &err)
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cvt.fontMetrics())
+}
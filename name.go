@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"golang.org/x/image/font/sfnt"
+)
+
+// englishNamePriority is the order of the name records to prefer: Windows
+// Unicode BMP in English (US), Windows in English (US) with any encoding, and
+// Macintosh Roman in English.
+var englishNamePriority = []struct {
+	platformID, encodingID, languageID uint16
+	anyEncoding                        bool
+}{
+	{platformID: 3, encodingID: 1, languageID: 0x0409},
+	{platformID: 3, languageID: 0x0409, anyEncoding: true},
+	{platformID: 1, encodingID: 0, languageID: 0},
+}
+
+// preferredName returns the name of the font for the name ID, preferring English
+// records. It falls back to sfnt.Font.Name, which takes the first record it
+// can decode in any language.
+func preferredName(raw *sfnttab.Font, fnt *sfnt.Font, id sfnt.NameID) (string, error) {
+	if names, err := raw.Names(uint16(id)); err == nil {
+		for _, p := range englishNamePriority {
+			for _, n := range names {
+				if n.PlatformID == p.platformID && n.LanguageID == p.languageID &&
+					(p.anyEncoding || n.EncodingID == p.encodingID) && n.Value != "" {
+					return n.Value, nil
+				}
+			}
+		}
+	}
+	return fnt.Name(nil, id)
+}
+
+// nameFallbacks are the name IDs to try in order, when the name ID of
+// WithNameID is absent.
+var nameFallbacks = []sfnt.NameID{sfnt.NameIDFamily, sfnt.NameIDFull, sfnt.NameIDPostScript}
+
+// lookupFamilyName returns the name of the font for the name ID, or the
+// first one found in nameFallbacks. It returns the error of the name ID when
+// none is found.
+func lookupFamilyName(raw *sfnttab.Font, fnt *sfnt.Font, id sfnt.NameID) (string, error) {
+	name, err := preferredName(raw, fnt, id)
+	if err == nil {
+		return name, nil
+	}
+	for _, fb := range nameFallbacks {
+		if fb == id {
+			continue
+		}
+		if name, err2 := preferredName(raw, fnt, fb); err2 == nil {
+			slog.Warn("fell back to another name record", "nameID", id, "fallback", fb, "err", err)
+			return name, nil
+		}
+	}
+	return "", err
+}
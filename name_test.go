@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+)
+
+// mockNameTable returns Go Regular with the records of the name IDs in
+// removed renumbered to unused IDs, as if the name table lacked them.
+func mockNameTable(t testing.TB, removed ...sfnt.NameID) []byte {
+	t.Helper()
+	data := bytes.Clone(goregular.TTF)
+	raw, err := sfnttab.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tab := range raw.Tables {
+		if tab.Tag != "name" {
+			continue
+		}
+		name := data[tab.Offset : tab.Offset+tab.Length]
+		for i := range int(binary.BigEndian.Uint16(name[2:])) {
+			rec := name[6+i*12:]
+			id := sfnt.NameID(binary.BigEndian.Uint16(rec[6:]))
+			for _, r := range removed {
+				if id == r {
+					binary.BigEndian.PutUint16(rec[6:], uint16(0x100+id))
+				}
+			}
+		}
+		return data
+	}
+	t.Fatal("no name table")
+	return nil
+}
+
+func TestNameFallback(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		id      sfnt.NameID
+		removed []sfnt.NameID
+		want    string
+	}{
+		{"family", sfnt.NameIDFamily, nil, "Go"},
+		{"full", sfnt.NameIDFull, nil, "Go Regular"},
+		{"no family", sfnt.NameIDFamily, []sfnt.NameID{sfnt.NameIDFamily}, "Go Regular"},
+		{"no family and full", sfnt.NameIDFamily, []sfnt.NameID{sfnt.NameIDFamily, sfnt.NameIDFull}, "GoRegular"},
+		{"no full", sfnt.NameIDFull, []sfnt.NameID{sfnt.NameIDFull}, "Go"},
+		{"none", sfnt.NameIDFamily, []sfnt.NameID{sfnt.NameIDFamily, sfnt.NameIDFull, sfnt.NameIDPostScript}, "Unknown"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cvt, err := NewBDFConverterFromBytes(mockNameTable(t, tc.removed...), 16, WithNameID(tc.id))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cvt.Close()
+			if got := cvt.FamilyName(); got != tc.want {
+				t.Errorf("FamilyName() = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
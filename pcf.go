@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Types of PCF tables.
+const (
+	pcfProperties   = 1 << 0
+	pcfAccelerators = 1 << 1
+	pcfMetrics      = 1 << 2
+	pcfBitmaps      = 1 << 3
+	pcfBDFEncodings = 1 << 5
+)
+
+// pcfFormat is the format of all tables written: integers and bits are most
+// significant first, and the rows of glyphs are padded to bytes, as those of
+// bitimg.Image.
+const pcfFormat = 1<<2 | 1<<3
+
+// pcfNoGlyph is the glyph index of unencoded code points in BDF_ENCODINGS.
+const pcfNoGlyph = 0xffff
+
+// pcfMetric is an uncompressed metrics entry of PCF.
+type pcfMetric struct {
+	LeftBearing  int16
+	RightBearing int16
+	Width        int16
+	Ascent       int16
+	Descent      int16
+	Attributes   uint16
+}
+
+// pcfTable is a table of PCF being built.
+type pcfTable struct {
+	typ uint32
+	buf bytes.Buffer
+}
+
+// newPCFTable starts a table with its format, which is always little endian.
+func newPCFTable(typ uint32) *pcfTable {
+	t := &pcfTable{typ: typ}
+	binary.Write(&t.buf, binary.LittleEndian, uint32(pcfFormat))
+	return t
+}
+
+// put appends the values in the byte order of pcfFormat.
+func (t *pcfTable) put(values ...any) {
+	for _, v := range values {
+		binary.Write(&t.buf, binary.BigEndian, v)
+	}
+}
+
+// ConvertPCF converts the font to PCF, the compiled font format of X11, and
+// writes it to the file outName. It writes the tables which bdftopcf would
+// make of the BDF, so the font can be used by Xorg without bdftopcf. PCF
+// encodes only code points up to U+FFFF, so the glyphs above are omitted.
+func (cvt *BDFConverter) ConvertPCF(outName string) error {
+	recs, err := cvt.collectGlyphs()
+	if err != nil {
+		return err
+	}
+	n := 0
+	for _, rec := range recs {
+		if rec.r <= 0xffff {
+			recs[n] = rec
+			n++
+		}
+	}
+	if n < len(recs) {
+		slog.Warn("omitted glyphs above U+FFFF, which PCF can't encode", "glyphs", len(recs)-n)
+	}
+	recs = recs[:n]
+
+	f, err := os.Create(outName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	if err := cvt.writePCF(bw, recs); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writePCF writes the glyphs as PCF.
+func (cvt *BDFConverter) writePCF(w io.Writer, recs []glyphRecord) error {
+	scale := cvt.pixelScale
+	height := cvt.height * scale
+	descent := -cvt.yOffset() * scale
+	metrics := make([]pcfMetric, len(recs))
+	widthSum := 0
+	for i, rec := range recs {
+		width := rec.width * scale
+		metrics[i] = pcfMetric{
+			RightBearing: int16(width),
+			Width:        int16(width),
+			Ascent:       int16(height - descent),
+			Descent:      int16(descent),
+		}
+		widthSum += rec.width
+	}
+	averageWidth := 0
+	if len(recs) > 0 {
+		averageWidth = widthSum * scale * 10 / len(recs)
+	}
+
+	tables := []*pcfTable{
+		cvt.pcfPropertiesTable(averageWidth),
+		pcfAcceleratorsTable(metrics, height-descent, descent),
+		pcfMetricsTable(metrics),
+		cvt.pcfBitmapsTable(recs),
+		cvt.pcfEncodingsTable(recs),
+	}
+
+	// The tables follow the header and the table of contents, aligned to 4
+	// bytes.
+	offset := 8 + 16*len(tables)
+	header := &bytes.Buffer{}
+	header.WriteString("\x01fcp")
+	binary.Write(header, binary.LittleEndian, uint32(len(tables)))
+	for _, t := range tables {
+		for t.buf.Len()%4 != 0 {
+			t.buf.WriteByte(0)
+		}
+		binary.Write(header, binary.LittleEndian, [4]uint32{t.typ, pcfFormat, uint32(t.buf.Len()), uint32(offset)})
+		offset += t.buf.Len()
+	}
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	for _, t := range tables {
+		if _, err := w.Write(t.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pcfPropertiesTable returns the PROPERTIES table: FONT, and the properties of
+// STARTPROPERTIES block.
+func (cvt *BDFConverter) pcfPropertiesTable(averageWidth int) *pcfTable {
+	props := append([]fontProperty{{name: "FONT", str: cvt.fontLine(averageWidth), isString: true}}, cvt.fontProperties()...)
+	strs := &bytes.Buffer{}
+	addString := func(s string) int32 {
+		off := int32(strs.Len())
+		strs.WriteString(s)
+		strs.WriteByte(0)
+		return off
+	}
+	t := newPCFTable(pcfProperties)
+	t.put(int32(len(props)))
+	for _, p := range props {
+		name := addString(p.name)
+		if p.isString {
+			t.put(name, int8(1), addString(p.str))
+		} else {
+			t.put(name, int8(0), int32(p.value))
+		}
+	}
+	if len(props)%4 != 0 {
+		t.buf.Write(make([]byte, 4-len(props)%4))
+	}
+	t.put(int32(strs.Len()))
+	t.buf.Write(strs.Bytes())
+	return t
+}
+
+// pcfAcceleratorsTable returns the ACCELERATORS table, which summarizes the
+// metrics.
+func pcfAcceleratorsTable(metrics []pcfMetric, fontAscent, fontDescent int) *pcfTable {
+	var minb, maxb pcfMetric
+	for i, m := range metrics {
+		if i == 0 {
+			minb, maxb = m, m
+			continue
+		}
+		minb = pcfMetric{
+			LeftBearing:  min(minb.LeftBearing, m.LeftBearing),
+			RightBearing: min(minb.RightBearing, m.RightBearing),
+			Width:        min(minb.Width, m.Width),
+			Ascent:       min(minb.Ascent, m.Ascent),
+			Descent:      min(minb.Descent, m.Descent),
+		}
+		maxb = pcfMetric{
+			LeftBearing:  max(maxb.LeftBearing, m.LeftBearing),
+			RightBearing: max(maxb.RightBearing, m.RightBearing),
+			Width:        max(maxb.Width, m.Width),
+			Ascent:       max(maxb.Ascent, m.Ascent),
+			Descent:      max(maxb.Descent, m.Descent),
+		}
+	}
+	b := func(v bool) uint8 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	constantWidth := minb.Width == maxb.Width
+	terminal := constantWidth && minb.Ascent == maxb.Ascent && minb.Descent == maxb.Descent &&
+		int(maxb.Ascent) == fontAscent && int(maxb.Descent) == fontDescent
+	// Every glyph fills its cell, so no glyph overlaps the next one, or
+	// inks outside the cell.
+	t := newPCFTable(pcfAccelerators)
+	t.put(
+		uint8(1),                     // noOverlap
+		b(constantWidth && terminal), // constantMetrics
+		b(terminal),
+		b(constantWidth),
+		uint8(1), // inkInside
+		uint8(0), // inkMetrics
+		uint8(0), // drawDirection: left to right
+		uint8(0), // padding
+		int32(fontAscent),
+		int32(fontDescent),
+		int32(0), // maxOverlap
+		minb,
+		maxb,
+	)
+	return t
+}
+
+// pcfMetricsTable returns the METRICS table of the glyphs.
+func pcfMetricsTable(metrics []pcfMetric) *pcfTable {
+	t := newPCFTable(pcfMetrics)
+	t.put(int32(len(metrics)), metrics)
+	return t
+}
+
+// pcfBitmapsTable returns the BITMAPS table of the glyphs.
+func (cvt *BDFConverter) pcfBitmapsTable(recs []glyphRecord) *pcfTable {
+	offsets := make([]int32, len(recs))
+	data := &bytes.Buffer{}
+	// The sizes of the bitmaps, when the rows are padded to 1, 2, 4 and 8
+	// bytes.
+	var sizes [4]int32
+	for i, rec := range recs {
+		img := cvt.scaleImage(rec.img)
+		offsets[i] = int32(data.Len())
+		data.Write(img.Bytes())
+		rowBytes, rows := img.Xn(), img.Bounds().Dy()
+		for j := range sizes {
+			pad := 1 << j
+			sizes[j] += int32((rowBytes + pad - 1) / pad * pad * rows)
+		}
+	}
+	t := newPCFTable(pcfBitmaps)
+	t.put(int32(len(recs)), offsets, sizes)
+	t.buf.Write(data.Bytes())
+	return t
+}
+
+// pcfEncodingsTable returns the BDF_ENCODINGS table, which maps the values of
+// ENCODING to the glyphs. The first and the second bytes are the upper and the
+// lower 8 bits of the code points. The glyphs of ENCODING -1 aren't mapped.
+func (cvt *BDFConverter) pcfEncodingsTable(recs []glyphRecord) *pcfTable {
+	minByte1, maxByte1, minByte2, maxByte2 := 0xff, 0, 0xff, 0
+	mapped := 0
+	for _, rec := range recs {
+		enc := cvt.encoding(rec.r)
+		if enc < 0 {
+			continue
+		}
+		mapped++
+		b1, b2 := enc>>8, enc&0xff
+		minByte1, maxByte1 = min(minByte1, b1), max(maxByte1, b1)
+		minByte2, maxByte2 = min(minByte2, b2), max(maxByte2, b2)
+	}
+	if mapped == 0 {
+		minByte1, maxByte1, minByte2, maxByte2 = 0, 0, 0, 0
+	}
+	cols := maxByte2 - minByte2 + 1
+	indices := make([]uint16, (maxByte1-minByte1+1)*cols)
+	for i := range indices {
+		indices[i] = pcfNoGlyph
+	}
+	for i, rec := range recs {
+		enc := cvt.encoding(rec.r)
+		if enc < 0 {
+			continue
+		}
+		b1, b2 := enc>>8, enc&0xff
+		indices[(b1-minByte1)*cols+b2-minByte2] = uint16(i)
+	}
+	t := newPCFTable(pcfBDFEncodings)
+	t.put(int16(minByte2), int16(maxByte2), int16(minByte1), int16(maxByte1), uint16(cvt.encoding(cvt.defaultChar)), indices)
+	return t
+}
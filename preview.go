@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// runPreview runs "preview" subcommand, which prints the bitmap of a glyph
+// as text, without writing BDF.
+func runPreview(args []string) error {
+	var (
+		size    int
+		on, off string
+	)
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	fs.IntVar(&size, "size", 16, `font size`)
+	fs.StringVar(&on, "on", "█", `text for set pixels`)
+	fs.StringVar(&off, "off", " ", `text for unset pixels`)
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return errors.New("two arguments are required: the OTF/TTF file and the code point, like U+4E2D")
+	}
+	r, err := parsePreviewRune(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	return writePreview(os.Stdout, fs.Arg(0), size, r, on, off)
+}
+
+// writePreview writes the bitmap of the glyph of r in the font file name to
+// w, with on and off for set and unset pixels.
+func writePreview(w io.Writer, name string, size int, r rune, on, off string) (err error) {
+	cvt, err := NewBDFConverter(name, size)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(cvt, &err)
+	adv, ok := cvt.face.GlyphAdvance(r)
+	if !ok {
+		return fmt.Errorf("the font has no glyph for U+%04X", r)
+	}
+	img := cvt.newCell(cvt.cellWidth(adv))
+	cvt.renderGlyph(img, r)
+
+	_, err = strings.NewReplacer("1", on, "0", off).WriteString(w, img.String())
+	return err
+}
+
+// parsePreviewRune parses a code point like "U+4E2D", or a single character.
+func parsePreviewRune(s string) (rune, error) {
+	if utf8.RuneCountInString(s) == 1 {
+		r, _ := utf8.DecodeRuneInString(s)
+		return r, nil
+	}
+	return parseCodePoint(s)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePreview(t *testing.T) {
+	font := writeGoRegular(t)
+	var buf bytes.Buffer
+	if err := writePreview(&buf, font, 16, 'A', "#", "."); err != nil {
+		t.Fatal(err)
+	}
+	// The rows of U+0041 in BDF, from TestRoundTrip.
+	want := strings.Join([]string{
+		"................",
+		"................",
+		"................",
+		"................",
+		".....#..........",
+		"....###.........",
+		"....###.........",
+		"...##.#.........",
+		"...##.##........",
+		"...#..##........",
+		"..##...#........",
+		"..##...##.......",
+		".########.......",
+		".##.....##......",
+		".#......##......",
+		"##.......#......",
+	}, "\n") + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := writePreview(&buf, font, 16, '中', "#", "."); err == nil || !strings.Contains(err.Error(), "U+4E2D") {
+		t.Errorf("preview of U+4E2D: got %v; want an error of no glyph", err)
+	}
+}
+
+func TestParsePreviewRune(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want rune
+	}{
+		{"A", 'A'},
+		{"中", '中'},
+		{"U+4E2D", '中'},
+	} {
+		if got, err := parsePreviewRune(tc.s); err != nil || got != tc.want {
+			t.Errorf("parsePreviewRune(%q) = %U, %v; want %U", tc.s, got, err, tc.want)
+		}
+	}
+}
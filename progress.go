@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// ProgressFunc is called after each glyph is written, with the number of
+// glyphs done so far and the total number of glyphs. It is invoked serially,
+// from the goroutine writing the BDF, even when glyphs are rendered in
+// parallel.
+type ProgressFunc func(done, total int)
+
+// GlyphTimingFunc is called after each glyph is rendered, with the progress
+// and the time taken to render the last glyph.
+type GlyphTimingFunc func(done, total int, lastRune rune, lastDuration time.Duration)
+
+// glyphTimingStats accumulates rendering times of glyphs.
+type glyphTimingStats struct {
+	count   int
+	sum     time.Duration
+	slowest rune
+	max     time.Duration
+}
+
+func (st *glyphTimingStats) record(_, _ int, r rune, d time.Duration) {
+	st.count++
+	st.sum += d
+	if d > st.max {
+		st.slowest, st.max = r, d
+	}
+}
+
+func (st *glyphTimingStats) log() {
+	if st.count == 0 {
+		return
+	}
+	slog.Info("glyph rendering time",
+		"glyphs", st.count,
+		"total", st.sum,
+		"average", st.sum/time.Duration(st.count),
+		"slowest", fmt.Sprintf("U+%04X", st.slowest),
+		"slowestTime", st.max)
+}
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// newProgressBar returns a ProgressFunc which draws an ANSI progress bar to
+// w, updating it in place with "\r".
+func newProgressBar(w io.Writer, width int) ProgressFunc {
+	lastFilled, lastPercent := -1, -1
+	return func(done, total int) {
+		if total <= 0 {
+			return
+		}
+		filled := done * width / total
+		percent := done * 100 / total
+		if filled == lastFilled && percent == lastPercent && done != total {
+			return
+		}
+		lastFilled, lastPercent = filled, percent
+		fmt.Fprintf(w, "\r\x1b[K[%s%s] %d%% (%d/%d glyphs)",
+			strings.Repeat("█", filled), strings.Repeat("░", width-filled),
+			percent, done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// newProgressCounter returns a ProgressFunc which writes "\rdone/total" to w.
+func newProgressCounter(w io.Writer) ProgressFunc {
+	return func(done, total int) {
+		fmt.Fprintf(w, "\r%d/%d", done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// newProgressLogger returns a ProgressFunc which logs the progress every 10
+// percent, for use when the output is not a terminal.
+func newProgressLogger() ProgressFunc {
+	lastStep := -1
+	return func(done, total int) {
+		if total <= 0 {
+			return
+		}
+		step := done * 10 / total
+		if step == lastStep {
+			return
+		}
+		lastStep = step
+		slog.Info("converting", "percent", step*10, "done", done, "total", total)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// validatePropertyName checks that name is a valid name of a custom BDF
+// property: upper case letters, digits and underscores.
+func validatePropertyName(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid property name: empty")
+	}
+	for _, c := range name {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' {
+			return fmt.Errorf("invalid property name %q: must consist of upper case letters, digits and underscores", name)
+		}
+	}
+	return nil
+}
+
+// quoteProperty quotes a string value of a BDF property. Double quotes in the
+// value are escaped by doubling them.
+func quoteProperty(v string) string {
+	return `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
+}
+
+// fontProperty is a BDF property, which has either an integer or a string
+// value.
+type fontProperty struct {
+	name     string
+	value    int
+	str      string
+	isString bool
+}
+
+// String returns the property as a line of STARTPROPERTIES block.
+func (p fontProperty) String() string {
+	if p.isString {
+		return p.name + " " + quoteProperty(p.str)
+	}
+	return p.name + " " + strconv.Itoa(p.value)
+}
+
+// fontProperties returns the properties of the font: the standard ones, and
+// then the custom ones sorted by name. Custom properties override the
+// standard ones with the same names.
+func (cvt *BDFConverter) fontProperties() []fontProperty {
+	descent := cvt.fontDescent()
+	_, height := cvt.fontBox()
+	standard := []fontProperty{
+		// They agree with FONTBOUNDINGBOX, as X uses them for line spacing.
+		{name: "FONT_ASCENT", value: height*cvt.pixelScale - descent},
+		{name: "FONT_DESCENT", value: descent},
+	}
+	if cvt.hasDefaultChar {
+		standard = append(standard, fontProperty{name: "DEFAULT_CHAR", value: cvt.encoding(cvt.defaultChar)})
+	} else {
+		slog.Debug("omitted DEFAULT_CHAR without its glyph", "rune", fmt.Sprintf("U+%04X", cvt.defaultChar))
+	}
+	var props []fontProperty
+	for _, p := range standard {
+		if _, ok := cvt.properties[p.name]; !ok {
+			props = append(props, p)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(cvt.properties)) {
+		props = append(props, fontProperty{name: name, str: cvt.properties[name], isString: true})
+	}
+	return props
+}
+
+// propertyLines returns the lines of STARTPROPERTIES block.
+func (cvt *BDFConverter) propertyLines() []string {
+	var lines []string
+	for _, p := range cvt.fontProperties() {
+		lines = append(lines, p.String())
+	}
+	return lines
+}
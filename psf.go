@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/math/fixed"
+)
+
+// psf2Magic is the magic number of PSF2 (PC Screen Font version 2).
+var psf2Magic = [4]byte{0x72, 0xb5, 0x4a, 0x86}
+
+// psf2HasUnicodeTable is the flag of PSF2 header, which indicates the font
+// has the Unicode table after the glyphs.
+const psf2HasUnicodeTable = 0x01
+
+// psf2Header is the header of PSF2, in little endian.
+type psf2Header struct {
+	Magic      [4]byte
+	Version    uint32
+	HeaderSize uint32
+	Flags      uint32
+	Length     uint32
+	CharSize   uint32
+	Height     uint32
+	Width      uint32
+}
+
+// ConvertPSF converts the font to PSF2, the console font format of Linux, and
+// writes it to the file outName. PSF2 requires all glyphs to have the same
+// size, so every glyph gets the full width cell as WithMono.
+func (cvt *BDFConverter) ConvertPSF(outName string) error {
+	savedMono := cvt.mono
+	cvt.mono = true
+	recs, err := cvt.collectGlyphs()
+	cvt.mono = savedMono
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(outName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	if err := cvt.writePSF2(bw, recs); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// collectGlyphs renders the glyphs through writeBody, without writing BDF,
+// and returns them. The observer of cvt is still called for each glyph.
+func (cvt *BDFConverter) collectGlyphs() ([]glyphRecord, error) {
+	saved := cvt.observe
+	defer func() { cvt.observe = saved }()
+	var recs []glyphRecord
+	add := collect(&recs)
+	cvt.observe = func(r rune, adv fixed.Int26_6, width int, img *bitimg.Image) {
+		add(r, adv, width, img)
+		if saved != nil {
+			saved(r, adv, width, img)
+		}
+	}
+	total, _ := cvt.countGlyphs()
+	if err := cvt.writeBody(io.Discard, total, nil); err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// writePSF2 writes the glyphs as PSF2, with the Unicode table which maps each
+// glyph to its rune. The unmapped glyphs have empty entries.
+func (cvt *BDFConverter) writePSF2(w io.Writer, recs []glyphRecord) error {
+	width, height := cvt.fullWidth*cvt.pixelScale, cvt.height*cvt.pixelScale
+	charSize := (width + 7) / 8 * height
+	h := psf2Header{
+		Magic:      psf2Magic,
+		HeaderSize: uint32(binary.Size(psf2Header{})),
+		Flags:      psf2HasUnicodeTable,
+		Length:     uint32(len(recs)),
+		CharSize:   uint32(charSize),
+		Height:     uint32(height),
+		Width:      uint32(width),
+	}
+	if err := binary.Write(w, binary.LittleEndian, &h); err != nil {
+		return err
+	}
+	for _, rec := range recs {
+		// The rows of bitimg.Image are padded to bytes, as those of PSF2.
+		if _, err := w.Write(cvt.scaleImage(rec.img).Bytes()); err != nil {
+			return err
+		}
+	}
+	for _, rec := range recs {
+		var b []byte
+		if rec.r >= 0 {
+			b = utf8.AppendRune(b, rec.r)
+		}
+		if _, err := w.Write(append(b, 0xff)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestConvertPSF(t *testing.T) {
+	only := WithRuneFilter(inRuneRanges([]runeRange{{'A', 'B'}}))
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, only)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	out := filepath.Join(t.TempDir(), "out.psf")
+	if err := cvt.ConvertPSF(out); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte{0x72, 0xb5, 0x4a, 0x86}) {
+		t.Fatalf("magic % x; want 72 b5 4a 86", data[:min(len(data), 4)])
+	}
+	var h psf2Header
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &h); err != nil {
+		t.Fatal(err)
+	}
+	want := psf2Header{
+		Magic:      psf2Magic,
+		Version:    0,
+		HeaderSize: 32,
+		Flags:      psf2HasUnicodeTable,
+		Length:     2,
+		CharSize:   32,
+		Height:     16,
+		Width:      16,
+	}
+	if h != want {
+		t.Fatalf("header %+v; want %+v", h, want)
+	}
+	glyphs := data[h.HeaderSize : h.HeaderSize+h.Length*h.CharSize]
+	if got, want := string(data[len(data)-4:]), "A\xffB\xff"; got != want || len(data) != int(h.HeaderSize)+len(glyphs)+4 {
+		t.Errorf("Unicode table %q of %d bytes; want %q after the glyphs", got, len(data), want)
+	}
+	// The glyph of U+0041 agrees with the bitmap of BDF.
+	g := findGlyph(t, convertGoRegular(t, 16, only), 'A')
+	if got, want := hex.EncodeToString(glyphs[:h.CharSize]), strings.ToLower(strings.Join(g.Bitmap, "")); got != want {
+		t.Errorf("glyph of U+0041 %s; want %s", got, want)
+	}
+}
+
+func TestConvertPSFPCFUnmapped(t *testing.T) {
+	opts := []Option{WithRuneFilter(inRuneRanges([]runeRange{{'A', 'B'}})), WithIncludeUnmapped()}
+	chars := convertGoRegular(t, 16, opts...).Chars
+	if chars <= 2 {
+		t.Fatalf("CHARS %d; want the unmapped glyphs after U+0041 and U+0042", chars)
+	}
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	dir := t.TempDir()
+
+	psf := filepath.Join(dir, "out.psf")
+	if err := cvt.ConvertPSF(psf); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(psf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var h psf2Header
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &h); err != nil {
+		t.Fatal(err)
+	}
+	if int(h.Length) != chars {
+		t.Errorf("PSF has %d glyphs; want CHARS %d", h.Length, chars)
+	}
+	table := string(data[h.HeaderSize+h.Length*h.CharSize:])
+	if want := "A\xffB\xff" + strings.Repeat("\xff", chars-2); table != want {
+		t.Errorf("PSF Unicode table %q; want %q", table, want)
+	}
+
+	pcf := filepath.Join(dir, "out.pcf")
+	if err := cvt.ConvertPCF(pcf); err != nil {
+		t.Fatal(err)
+	}
+	if data, err = os.ReadFile(pcf); err != nil {
+		t.Fatal(err)
+	}
+	tables := binary.LittleEndian.Uint32(data[4:])
+	for i := range tables {
+		toc := data[8+16*i:]
+		if binary.LittleEndian.Uint32(toc) != pcfBitmaps {
+			continue
+		}
+		// The count of the glyphs follows the format of the table.
+		offset := binary.LittleEndian.Uint32(toc[12:])
+		if n := int32(binary.BigEndian.Uint32(data[offset+4:])); int(n) != chars {
+			t.Errorf("PCF has %d glyphs; want CHARS %d", n, chars)
+		}
+		return
+	}
+	t.Error("PCF has no BITMAPS table")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// runeRange is an inclusive range of code points.
+type runeRange struct {
+	lo, hi rune
+}
+
+func (rr runeRange) String() string {
+	return fmt.Sprintf("U+%04X-U+%04X", rr.lo, rr.hi)
+}
+
+// parseCodePoint parses a code point like "U+0020".
+func parseCodePoint(s string) (rune, error) {
+	hex, ok := strings.CutPrefix(strings.ToUpper(s), "U+")
+	if !ok {
+		return 0, fmt.Errorf("invalid code point %q: must start with \"U+\"", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || n > 0x10ffff {
+		return 0, fmt.Errorf("invalid code point %q", s)
+	}
+	return rune(n), nil
+}
+
+// parseRuneRange parses a range like "U+0020-U+007E", or a single code point.
+func parseRuneRange(s string) (runeRange, error) {
+	first, last, ok := strings.Cut(s, "-")
+	lo, err := parseCodePoint(first)
+	if err != nil {
+		return runeRange{}, err
+	}
+	hi := lo
+	if ok {
+		hi, err = parseCodePoint(last)
+		if err != nil {
+			return runeRange{}, err
+		}
+	}
+	if lo > hi {
+		return runeRange{}, fmt.Errorf("reversed range %q", s)
+	}
+	return runeRange{lo: lo, hi: hi}, nil
+}
+
+// checkRuneRanges returns an error when some of the ranges overlap.
+func checkRuneRanges(ranges []runeRange) error {
+	sorted := slices.SortedFunc(slices.Values(ranges), func(a, b runeRange) int {
+		return cmp.Compare(a.lo, b.lo)
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].lo <= sorted[i-1].hi {
+			return fmt.Errorf("overlapping ranges %s and %s", sorted[i-1], sorted[i])
+		}
+	}
+	return nil
+}
+
+// inRuneRanges returns a filter of runeIter, which accepts the runes in any of
+// the ranges.
+func inRuneRanges(ranges []runeRange) func(rune) bool {
+	return func(r rune) bool {
+		for _, rr := range ranges {
+			if rr.lo <= r && r <= rr.hi {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// readCharsFile reads a UTF-8 text file of the characters to convert. Each
+// line is a single character, a code point like "U+0041" or a range like
+// "U+0041-U+005A". Blank lines and lines starting with "#" are skipped, so
+// "U+0023" is the way to list "#" itself.
+func readCharsFile(name string) (map[rune]bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	chars := map[rune]bool{}
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if utf8.RuneCountInString(line) == 1 {
+			r, _ := utf8.DecodeRuneInString(line)
+			chars[r] = true
+			continue
+		}
+		rr, err := parseRuneRange(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		for r := rr.lo; r <= rr.hi; r++ {
+			chars[r] = true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return chars, nil
+}
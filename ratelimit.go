@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter caps the number of glyphs rendered per second. All goroutines
+// take ticks from the same ticker, so the limit is shared by the workers of
+// -jobs and by the conversions of ConvertAll which use the same Option.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu sync.Mutex
+	// users is the number of the conversions between start and stop.
+	users  int
+	ticker *time.Ticker
+}
+
+func newRateLimiter(glyphsPerSecond int) *rateLimiter {
+	// Rates above a glyph per nanosecond are as good as no limit, but
+	// time.NewTicker panics with 0.
+	return &rateLimiter{interval: max(time.Second/time.Duration(glyphsPerSecond), 1)}
+}
+
+// start registers a conversion which calls wait, until it calls stop.
+func (rl *rateLimiter) start() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.users++
+}
+
+// stop unregisters a conversion registered by start, and stops the ticker
+// when no conversions are left.
+func (rl *rateLimiter) stop() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.users--
+	if rl.users == 0 && rl.ticker != nil {
+		rl.ticker.Stop()
+		rl.ticker = nil
+	}
+}
+
+// wait blocks until the next glyph may be rendered. The ticker starts on the
+// first call, which doesn't wait.
+func (rl *rateLimiter) wait() {
+	rl.mu.Lock()
+	t := rl.ticker
+	if t == nil {
+		rl.ticker = time.NewTicker(rl.interval)
+	}
+	rl.mu.Unlock()
+	if t != nil {
+		<-t.C
+	}
+}
+
+// WithRateLimit caps the glyph rendering throughput to glyphsPerSecond. The
+// limit is shared by all the converters created with the returned Option, so
+// passing it to each FontSpec of ConvertAll caps the whole batch. It does
+// nothing when glyphsPerSecond <= 0.
+func WithRateLimit(glyphsPerSecond int) Option {
+	if glyphsPerSecond <= 0 {
+		return func(*BDFConverter) {}
+	}
+	rl := newRateLimiter(glyphsPerSecond)
+	return func(cvt *BDFConverter) {
+		cvt.rateLimit = rl
+	}
+}
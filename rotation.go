@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// WithRotation rotates the glyphs clockwise by degrees, 90, 180 or 270, for
+// displays mounted in portrait or upside down. By 90 and 270 degrees, every
+// glyph gets the height of the font as its DWIDTH, and the full width as the
+// height of FONTBOUNDINGBOX. The glyphs rotated from half width cells are
+// aligned to the side which was the left of the cell.
+func WithRotation(degrees int) Option {
+	return func(cvt *BDFConverter) {
+		cvt.rotation = degrees
+	}
+}
+
+// swapsAxes reports whether WithRotation swaps the width and the height.
+func (cvt *BDFConverter) swapsAxes() bool {
+	return cvt.rotation == 90 || cvt.rotation == 270
+}
+
+// fontBox returns the width and the height of FONTBOUNDINGBOX before
+// WithPixelDoubling. With WithPow2Width, the width covers the BBX padded by
+// writeEntry, which may be wider than the cell.
+func (cvt *BDFConverter) fontBox() (width, height int) {
+	width, height = cvt.fullWidth+cvt.italicExtra(), cvt.height
+	if cvt.pow2Width {
+		n := cvt.pixelScale
+		width = (nextPow2(width*n) + n - 1) / n
+	}
+	if cvt.swapsAxes() {
+		return height, width
+	}
+	return width, height
+}
+
+// rotateCell rotates the cell img of WithRotation. It returns the rotated
+// cell, its DWIDTH, and the height of the cell which its bottom is placed at,
+// in the box of fontBox.
+func (cvt *BDFConverter) rotateCell(img *bitimg.Image, width int) (*bitimg.Image, int, int) {
+	_, boxHeight := cvt.fontBox()
+	switch cvt.rotation {
+	case 90:
+		// The left of the cell is at the top of the box.
+		return img.Rotate90(), cvt.height, boxHeight
+	case 180:
+		return img.Rotate180(), width, cvt.height
+	case 270:
+		// The left of the cell is at the bottom of the box.
+		return img.Rotate270(), cvt.height, img.Bounds().Dx()
+	}
+	return img, width, cvt.height
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// Rounding is a mode of rounding advances to pixels.
+type Rounding int
+
+const (
+	// RoundNearest rounds to the nearest pixel, and halves up, as
+	// fixed.Int26_6.Round does.
+	RoundNearest Rounding = iota
+	// RoundFloor truncates the fraction.
+	RoundFloor
+	// RoundCeil rounds up any fraction.
+	RoundCeil
+)
+
+// WithRounding sets the mode of rounding the advances of glyphs to pixels,
+// to classify them as half or full width. The default is RoundNearest.
+func WithRounding(mode Rounding) Option {
+	return func(cvt *BDFConverter) {
+		cvt.rounding = mode
+	}
+}
+
+// parseRounding parses the name of a rounding mode: "round", "floor" or
+// "ceil".
+func parseRounding(s string) (Rounding, error) {
+	switch s {
+	case "round":
+		return RoundNearest, nil
+	case "floor":
+		return RoundFloor, nil
+	case "ceil":
+		return RoundCeil, nil
+	default:
+		return 0, fmt.Errorf("unknown rounding mode: %q", s)
+	}
+}
+
+// roundAdv rounds the advance to pixels with the mode.
+func roundAdv(adv fixed.Int26_6, mode Rounding) int {
+	switch mode {
+	case RoundFloor:
+		return adv.Floor()
+	case RoundCeil:
+		return adv.Ceil()
+	default:
+		return adv.Round()
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestRoundAdv(t *testing.T) {
+	for _, tc := range []struct {
+		adv                fixed.Int26_6
+		round, floor, ceil int
+	}{
+		{fixed.I(8), 8, 8, 8},
+		{fixed.I(8) + 1, 8, 8, 9},
+		{fixed.I(8) + 31, 8, 8, 9},
+		// The exactly half advance is rounded up by RoundNearest.
+		{fixed.I(8) + 32, 9, 8, 9},
+		{fixed.I(8) + 33, 9, 8, 9},
+		{fixed.I(9) - 1, 9, 8, 9},
+	} {
+		for _, m := range []struct {
+			mode Rounding
+			want int
+		}{
+			{RoundNearest, tc.round},
+			{RoundFloor, tc.floor},
+			{RoundCeil, tc.ceil},
+		} {
+			if got := roundAdv(tc.adv, m.mode); got != m.want {
+				t.Errorf("roundAdv(%v, %d) = %d; want %d", tc.adv, m.mode, got, m.want)
+			}
+		}
+	}
+}
+
+func TestRoundingClassification(t *testing.T) {
+	// 8.5 pixels at the size 16: the default threshold of full width is 9.
+	half := fixed.I(8) + 32
+	for _, tc := range []struct {
+		name string
+		want bool
+	}{
+		{"round", true},
+		{"floor", false},
+		{"ceil", true},
+	} {
+		mode, err := parseRounding(tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, WithRounding(mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cvt.isFullWidth(half); got != tc.want {
+			t.Errorf("-rounding %s: isFullWidth(%v) = %t; want %t", tc.name, half, got, tc.want)
+		}
+		cvt.Close()
+	}
+	if _, err := parseRounding("even"); err == nil {
+		t.Errorf("parseRounding(\"even\"): got no error")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/koron/otf2ccbdf/internal/unicodeblocks"
+)
+
+// noBlockName is the name of the block of runes which are not in any block.
+const noBlockName = "No_Block"
+
+// blockName returns the name of the Unicode block of the rune r.
+func blockName(r rune) string {
+	if b, ok := unicodeblocks.Of(r); ok {
+		return b.Name
+	}
+	return noBlockName
+}
+
+// splitOutName returns the name of the file of the block: the name of the
+// block is inserted before the ".bdf" extension of outName, with spaces
+// replaced by underbars, like "font.Basic_Latin.bdf". The extension ".gz" is
+// kept.
+func splitOutName(outName, block string) string {
+	ext := ".bdf"
+	if isGzipName(outName) {
+		outName, ext = strings.TrimSuffix(outName, ".gz"), ".bdf.gz"
+	}
+	return strings.TrimSuffix(outName, ".bdf") + "." + strings.ReplaceAll(block, " ", "_") + ext
+}
+
+// ConvertByBlock converts the font to BDF files, one per Unicode block of the
+// glyphs, named by splitOutName. Each file has its own CHARS and average
+// width. The unmapped glyphs of WithIncludeUnmapped are written to the file
+// of the block "Unmapped".
+func (cvt *BDFConverter) ConvertByBlock(outName string) error {
+	var blocks []string
+	seen := map[string]bool{}
+	for r := range cvt.glyphs() {
+		if name := blockName(r); !seen[name] {
+			seen[name] = true
+			blocks = append(blocks, name)
+		}
+	}
+
+	filter, unmapped := cvt.filter, cvt.unmapped
+	defer func() { cvt.filter, cvt.unmapped = filter, unmapped }()
+	cvt.unmapped = nil
+	for _, block := range blocks {
+		cvt.filter = func(r rune) bool {
+			return (filter == nil || filter(r)) && blockName(r) == block
+		}
+		name := splitOutName(outName, block)
+		if err := cvt.Convert(name); err != nil {
+			return err
+		}
+		slog.Info("wrote the block", "block", block, "out", name)
+	}
+	if len(unmapped) > 0 {
+		cvt.filter = func(rune) bool { return false }
+		cvt.unmapped = unmapped
+		if err := cvt.Convert(splitOutName(outName, "Unmapped")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package main
+
+import "fmt"
+
+// sfntSubset returns a minimal font binary of the index-th font in b, which
+// contains only the outlines of runes, with cmap and metrics tables. The result
+// is a single font even when b is a collection.
+func sfntSubset(b []byte, index int, runes []rune) ([]byte, error) {
+	raw, err := parseRaw(b, index)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := raw.Subset(runes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subset the font: %w", err)
+	}
+	return sub, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// runBDF2SVG runs "bdf2svg" subcommand, which writes a glyph of a BDF file as
+// SVG, to reconstruct an approximate outline for editing.
+func runBDF2SVG(args []string) (err error) {
+	var (
+		outName string
+		scale   int
+	)
+	fs := flag.NewFlagSet("bdf2svg", flag.ExitOnError)
+	fs.StringVar(&outName, "out", "", `output SVG file (default stdout)`)
+	fs.IntVar(&scale, "scale", 1, `size of a pixel in the SVG units of width and height`)
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return errors.New("two arguments are required: the BDF file and the code point, like U+4E2D")
+	}
+	if scale < 1 {
+		return errors.New("-scale must be positive")
+	}
+	r, err := parsePreviewRune(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	f, err := openBDF(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	font, err := bdf.Parse(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	var glyph *bdf.Glyph
+	for _, g := range font.Glyphs {
+		if g.Encoding == int(r) {
+			glyph = g
+			break
+		}
+	}
+	if glyph == nil {
+		return fmt.Errorf("the BDF has no glyph for U+%04X", r)
+	}
+	img, err := glyphImage(glyph)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if outName != "" {
+		f, err := os.Create(outName)
+		if err != nil {
+			return err
+		}
+		defer closeKeepErr(f, &err)
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	if err := writeSVG(bw, img, scale); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeSVG writes the bitmap as a self-contained SVG, of which viewBox is the
+// size of the bitmap. The set pixels are traced as a path of rectangles, one
+// for each horizontal run of set pixels.
+func writeSVG(w io.Writer, img *bitimg.Image, scale int) error {
+	b := img.Bounds()
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		b.Dx()*scale, b.Dy()*scale, b.Dx(), b.Dy())
+	fmt.Fprint(w, `<path fill="black" d="`)
+	sep := ""
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !img.BitAt(x, y) {
+				continue
+			}
+			start := x
+			for x < b.Max.X && img.BitAt(x, y) {
+				x++
+			}
+			fmt.Fprintf(w, "%sM%d %dh%dv1h%dz", sep, start-b.Min.X, y-b.Min.Y, x-start, start-x)
+			sep = " "
+		}
+	}
+	_, err := fmt.Fprint(w, "\"/>\n</svg>\n")
+	return err
+}
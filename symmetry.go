@@ -0,0 +1,67 @@
+package main
+
+import (
+	"image"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+)
+
+// SymmetryReport has the symmetry scores of a glyph, from 0.0 (asymmetric)
+// to 1.0 (symmetric). Blank glyphs are reported as symmetric.
+type SymmetryReport struct {
+	Rune rune
+	// LeftRight is the symmetry about the vertical axis, as of 'A' or 'O'.
+	LeftRight float64
+	// TopBottom is the symmetry about the horizontal axis, as of 'B' or 'E'.
+	TopBottom float64
+}
+
+// AnalyzeSymmetry renders the glyph of the rune r, and compares it with its
+// mirrored images. The mirror axes pass through the center of the inked
+// pixels, so side bearings don't affect the scores.
+func (cvt *BDFConverter) AnalyzeSymmetry(r rune) SymmetryReport {
+	width := cvt.fullWidth
+	if adv, ok := cvt.face.GlyphAdvance(r); ok {
+		width = cvt.cellWidth(adv)
+	}
+	img := cvt.newCell(width)
+	cvt.renderGlyph(img, r)
+	ink := inkImage(img)
+	return SymmetryReport{
+		Rune:      r,
+		LeftRight: symmetryScore(ink, ink.FlipHorizontal()),
+		TopBottom: symmetryScore(ink, ink.FlipVertical()),
+	}
+}
+
+// inkImage returns a copy of the bounding box of the set pixels of img.
+func inkImage(img *bitimg.Image) *bitimg.Image {
+	ink := img.InkBounds()
+	dst := bitimg.New(image.Rect(0, 0, ink.Dx(), ink.Dy()))
+	for y := 0; y < ink.Dy(); y++ {
+		for x := 0; x < ink.Dx(); x++ {
+			dst.Set(x, y, img.BitAt(ink.Min.X+x, ink.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// symmetryScore returns the fraction of the set pixels of img which are set
+// in its mirrored image too.
+func symmetryScore(img, mirrored *bitimg.Image) float64 {
+	set := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.BitAt(x, y) {
+				set++
+			}
+		}
+	}
+	if set == 0 {
+		return 1
+	}
+	// Each asymmetric pixel differs at itself and at its mirrored position.
+	diff, _ := img.Diff(mirrored)
+	return 1 - float64(diff)/float64(2*set)
+}
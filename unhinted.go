@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"log/slog"
+	"math/bits"
+	"slices"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// runUnhinted writes an unhinted BDF of the font to outName, and logs the
+// glyphs whose hinted and unhinted bitmaps differ more than threshold pixels.
+// The progress and the timing of opts are reported only by the hinted
+// conversion.
+func runUnhinted(hinted *BDFConverter, inName, outName string, threshold int, opts ...Option) (err error) {
+	opts = append(slices.Clip(opts), WithHinting(font.HintingNone), WithProgress(nil), WithGlyphTiming(nil))
+	unhinted, err := NewBDFConverter(inName, hinted.size, opts...)
+	if err != nil {
+		return err
+	}
+	defer closeKeepErr(unhinted, &err)
+
+	var scratch *bitimg.Image
+	affected := 0
+	unhinted.observe = func(r rune, _ fixed.Int26_6, width int, img *bitimg.Image) {
+		if r < 0 {
+			// The unmapped glyphs are never hinted.
+			return
+		}
+		// img is styled, so style the hinted glyph the same way, in a cell of
+		// the width before WithItalic.
+		if cell := width - hinted.italicExtra(); scratch == nil || scratch.Bounds().Dx() != cell {
+			scratch = bitimg.NewWithThreshold(image.Rect(0, 0, cell, hinted.height), hinted.threshold)
+		}
+		hinted.render(scratch, r)
+		want := hinted.stylize(scratch)
+		if hinted.invert {
+			want.Invert()
+		}
+		diff := 0
+		for i, b := range want.Bytes() {
+			diff += bits.OnesCount8(b ^ img.Bytes()[i])
+		}
+		if diff > threshold {
+			affected++
+			slog.Info("hinting-affected", "rune", fmt.Sprintf("U+%04X", r), "diff", diff)
+		}
+	}
+	if err := unhinted.Convert(outName); err != nil {
+		return err
+	}
+	slog.Info("hinting diagnostics", "affected", affected, "threshold", threshold)
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// unmappedGlyphs returns the glyph indices which no code point maps to in the
+// cmap, except for .notdef (0).
+func (cvt *BDFConverter) unmappedGlyphs() ([]sfnt.GlyphIndex, error) {
+	mappings, err := cvt.raw.CMap()
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]bool, cvt.fnt.NumGlyphs())
+	for _, m := range mappings {
+		if int(m.Glyph) < len(mapped) {
+			mapped[m.Glyph] = true
+		}
+	}
+	var gids []sfnt.GlyphIndex
+	for gid := 1; gid < len(mapped); gid++ {
+		if !mapped[gid] {
+			gids = append(gids, sfnt.GlyphIndex(gid))
+		}
+	}
+	return gids, nil
+}
+
+// unmappedName returns the name of the glyph gid in STARTCHAR.
+func unmappedName(gid sfnt.GlyphIndex) string {
+	return fmt.Sprintf("glyph%d", gid)
+}
+
+// glyphIndexAdvance returns the advance of the glyph gid at the size.
+func (cvt *BDFConverter) glyphIndexAdvance(gid sfnt.GlyphIndex) (fixed.Int26_6, error) {
+	var buf sfnt.Buffer
+	return cvt.fnt.GlyphAdvance(&buf, gid, fixed.I(cvt.size), cvt.hinting)
+}
+
+// glyphIndexBounds returns the outline bounds of the glyph gid at the size,
+// relative to the pen as font.Face.GlyphBounds.
+func (cvt *BDFConverter) glyphIndexBounds(gid sfnt.GlyphIndex) (fixed.Rectangle26_6, error) {
+	var buf sfnt.Buffer
+	segs, err := cvt.fnt.LoadGlyph(&buf, gid, fixed.I(cvt.size), &sfnt.LoadGlyphOptions{})
+	if err != nil {
+		return fixed.Rectangle26_6{}, err
+	}
+	return segs.Bounds(), nil
+}
+
+// renderGlyphIndex clears img and draws the glyph gid on it, with the pen at
+// (0, ascent) as render does. Unmapped glyphs have no rune to draw with
+// font.Drawer, so the outline is rasterized directly. Like the faces of
+// opentype, which hint only advances and metrics, the outline isn't hinted,
+// so the hinting mode applies to unmapped glyphs as to the others.
+func (cvt *BDFConverter) renderGlyphIndex(img *bitimg.Image, gid sfnt.GlyphIndex) error {
+	img.Clear()
+	var buf sfnt.Buffer
+	segs, err := cvt.fnt.LoadGlyph(&buf, gid, fixed.I(cvt.size), &sfnt.LoadGlyphOptions{})
+	if err != nil {
+		return err
+	}
+	b := img.Bounds()
+	ox, oy := float32(-b.Min.X), float32(cvt.ascent-b.Min.Y)
+	pt := func(p fixed.Point26_6) (float32, float32) {
+		return float32(p.X)/64 + ox, float32(p.Y)/64 + oy
+	}
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	for _, seg := range segs {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			z.MoveTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			z.LineTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			z.QuadTo(x1, y1, x2, y2)
+		case sfnt.SegmentOpCubeTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			x3, y3 := pt(seg.Args[2])
+			z.CubeTo(x1, y1, x2, y2, x3, y3)
+		}
+	}
+	z.ClosePath()
+	alpha := image.NewAlpha(image.Rect(0, 0, b.Dx(), b.Dy()))
+	z.Draw(alpha, alpha.Bounds(), image.Opaque, image.Point{})
+	gray := image.NewGray(alpha.Bounds())
+	draw.Draw(gray, gray.Bounds(), alpha, image.Point{}, draw.Src)
+	return img.Threshold(gray, cvt.threshold)
+}
+
+// writeUnmapped writes the glyphs of WithIncludeUnmapped, checked, styled and
+// observed as those of writeBody. The issues of the glyphs are counted in
+// issues. written is called after each glyph is written, with -1 as the rune.
+func (cvt *BDFConverter) writeUnmapped(w io.Writer, issues *glyphIssues, written func(rune, time.Duration) error) error {
+	for _, gid := range cvt.unmapped {
+		if cvt.blankGlyphs[gid] {
+			continue
+		}
+		adv, err := cvt.glyphIndexAdvance(gid)
+		if err != nil {
+			slog.Warn("skipped an unmapped glyph without advance", "glyph", gid, "err", err)
+			continue
+		}
+		width := cvt.cellWidth(adv)
+		img := cvt.newCell(width)
+		if cvt.rateLimit != nil {
+			cvt.rateLimit.wait()
+		}
+		start := time.Now()
+		if err := cvt.renderGlyphIndex(img, gid); err != nil {
+			return fmt.Errorf("failed to render glyph %d: %w", gid, err)
+		}
+		elapsed := time.Since(start)
+		if cvt.skipsGlyph(-1, img) {
+			continue
+		}
+		if err := cvt.checkGlyphIndexFit(gid, width, issues); err != nil {
+			return err
+		}
+		if cvt.stylized() {
+			img = cvt.stylize(img)
+			width += cvt.italicExtra()
+		}
+		if cvt.invert {
+			img.Invert()
+		}
+		if cvt.debugDir != "" {
+			if err := writeDebugPNG(filepath.Join(cvt.debugDir, unmappedName(gid)+".png"), img); err != nil {
+				return err
+			}
+		}
+
+		vadv := 0
+		if cvt.vm != nil {
+			vadv = cvt.glyphVerticalAdvance(gid)
+		} else if cvt.bdfVersion == "2.2" {
+			vadv = cvt.height
+		}
+		var exactAdv fixed.Int26_6
+		if cvt.exactSWidth {
+			exactAdv = adv
+		}
+		entry := img
+		if cvt.glyphBBX {
+			var rect image.Rectangle
+			if b, err := cvt.glyphIndexBounds(gid); err == nil {
+				rect = cvt.outlineRect(b)
+			}
+			entry = cvt.cropGlyphBBX(img, rect)
+		}
+		if err := cvt.writeEntry(w, unmappedName(gid), -1, vadv, width, exactAdv, entry); err != nil {
+			return err
+		}
+		if cvt.observe != nil {
+			cvt.observe(-1, adv, width, img)
+		}
+		if err := written(-1, elapsed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
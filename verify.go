@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"log/slog"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+	"github.com/koron/otf2ccbdf/internal/bitimg"
+	"golang.org/x/image/font/sfnt"
+)
+
+// glyphImage decodes the bitmap of the BDF glyph. The rows padded by
+// WithRowAlign are trimmed to the bytes of the BBX width.
+func glyphImage(g *bdf.Glyph) (*bitimg.Image, error) {
+	w, h := g.BBX[0], g.BBX[1]
+	xn := (w + 7) / 8
+	data := make([]byte, 12, 12+xn*h)
+	binary.BigEndian.PutUint32(data[0:], uint32(w))
+	binary.BigEndian.PutUint32(data[4:], uint32(h))
+	binary.BigEndian.PutUint32(data[8:], uint32(xn))
+	for _, row := range g.Bitmap {
+		b, err := hex.DecodeString(row)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", g.Name, err)
+		}
+		if len(b) > xn {
+			b = b[:xn]
+		}
+		data = append(data, b...)
+	}
+	img := &bitimg.Image{}
+	if err := img.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", g.Name, err)
+	}
+	return img, nil
+}
+
+// Verify parses the BDF file written by Convert, and compares its bitmaps
+// with re-rendered glyphs. It fails when the rate of differing pixels
+// exceeds tolerance.
+func (cvt *BDFConverter) Verify(name string, tolerance float64) error {
+	f, err := openBDF(name)
+	if err != nil {
+		return err
+	}
+	font, err := bdf.Parse(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	var diffPixels, totalPixels, diffGlyphs int
+	for _, g := range font.Glyphs {
+		got, err := glyphImage(g)
+		if err != nil {
+			return err
+		}
+		want, err := cvt.renderVerified(g)
+		if err != nil {
+			return err
+		}
+		want = cvt.stylize(want)
+		if cvt.invert {
+			want.Invert()
+		}
+		want = cvt.scaleImage(want)
+		if cvt.glyphBBX || cvt.tightBBX {
+			// Compare only the part of the cell in BBX.
+			n := cvt.pixelScale
+			bottom := cvt.height*n - (g.BBX[3] - cvt.yOffset()*n)
+			part := image.Rect(g.BBX[2], bottom-g.BBX[1], g.BBX[2]+g.BBX[0], bottom)
+			want = want.Crop(part).Pad(got.Bounds().Dx(), got.Bounds().Dy())
+		} else {
+			want = want.Pad(got.Bounds().Dx(), got.Bounds().Dy())
+		}
+		n, err := want.Diff(got)
+		if err != nil {
+			return fmt.Errorf("%s: %w", g.Name, err)
+		}
+		if n > 0 {
+			diffGlyphs++
+			slog.Debug("glyph differs", "glyph", g.Name, "pixels", n)
+		}
+		diffPixels += n
+		totalPixels += got.Bounds().Dx() * got.Bounds().Dy()
+	}
+	rate := 0.0
+	if totalPixels > 0 {
+		rate = float64(diffPixels) / float64(totalPixels)
+	}
+	slog.Info("verified", "glyphs", len(font.Glyphs), "differentGlyphs", diffGlyphs, "errorRate", rate)
+	if rate > tolerance {
+		return fmt.Errorf("verification failed: pixel error rate %g exceeds tolerance %g", rate, tolerance)
+	}
+	return nil
+}
+
+// renderVerified renders the glyph of the font for the BDF glyph g, which is
+// an unmapped glyph of WithIncludeUnmapped when ENCODING is -1.
+func (cvt *BDFConverter) renderVerified(g *bdf.Glyph) (*bitimg.Image, error) {
+	if g.Encoding == -1 {
+		// The name of the glyph is of unmappedName.
+		var gid sfnt.GlyphIndex
+		if _, err := fmt.Sscanf(g.Name, "glyph%d", &gid); err != nil {
+			return nil, fmt.Errorf("%s: not the name of an unmapped glyph: %w", g.Name, err)
+		}
+		adv, err := cvt.glyphIndexAdvance(gid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", g.Name, err)
+		}
+		img := cvt.newCell(cvt.cellWidth(adv))
+		if err := cvt.renderGlyphIndex(img, gid); err != nil {
+			return nil, fmt.Errorf("%s: %w", g.Name, err)
+		}
+		return img, nil
+	}
+	r := cvt.decodeEncoding(g.Encoding)
+	adv, ok := cvt.face.GlyphAdvance(r)
+	if !ok {
+		return nil, fmt.Errorf("%s: the font has no glyph for ENCODING %d", g.Name, g.Encoding)
+	}
+	img := cvt.newCell(cvt.cellWidth(adv))
+	cvt.renderGlyph(img, r)
+	return img, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/koron/otf2ccbdf/internal/bdf"
+)
+
+func TestGlyphImageRowAlign(t *testing.T) {
+	// A 10 pixels wide row padded to 4 bytes.
+	g := &bdf.Glyph{Name: "U+0041", BBX: [4]int{10, 2, 0, 0}, Bitmap: []string{"80400000", "00C00000"}}
+	img, err := glyphImage(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.String(), "1000000001\n0000000011\n"; got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunVerifyRowAlign(t *testing.T) {
+	font := writeGoRegular(t)
+	out := filepath.Join(t.TempDir(), "out.bdf")
+	if err := Run(context.Background(), []string{"-quiet", "-verify", "-row-align", "4", "-range", "U+0000-U+007F", "-out", out, font}); err != nil {
+		t.Fatal(err)
+	}
+}
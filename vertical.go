@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/binary"
+
+	"github.com/koron/otf2ccbdf/internal/sfnttab"
+)
+
+// vmetrics holds vertical advances in font units, read from vhea and vmtx.
+type vmetrics struct {
+	advances []uint16
+}
+
+// readVMetrics reads the vertical metrics tables. It returns nil when the
+// font doesn't have valid vhea and vmtx tables.
+func readVMetrics(raw *sfnttab.Font) *vmetrics {
+	vhea := raw.Table("vhea")
+	vmtx := raw.Table("vmtx")
+	if len(vhea) < 36 {
+		return nil
+	}
+	n := int(binary.BigEndian.Uint16(vhea[34:]))
+	if n == 0 || len(vmtx) < n*4 {
+		return nil
+	}
+	advances := make([]uint16, n)
+	for i := range advances {
+		advances[i] = binary.BigEndian.Uint16(vmtx[i*4:])
+	}
+	return &vmetrics{advances: advances}
+}
+
+// advance returns the vertical advance of the glyph in font units.
+func (vm *vmetrics) advance(gid int) int {
+	if gid >= len(vm.advances) {
+		// Glyphs after numOfLongVerMetrics share the last advance.
+		gid = len(vm.advances) - 1
+	}
+	return int(vm.advances[gid])
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// watchInterval is the interval of polling the file, and also the delay to
+// debounce consecutive writes.
+const watchInterval = 200 * time.Millisecond
+
+// fileStamp identifies a version of a file by its modified time and size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statStamp(name string) (fileStamp, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
+
+// watchFile calls fn once, and again whenever the file name changes, until
+// ctx is canceled. The file is polled, so it works on any platform. A change
+// is reported after the file stays unchanged for watchInterval, to debounce
+// consecutive writes.
+func watchFile(ctx context.Context, name string, fn func()) error {
+	last, err := statStamp(name)
+	if err != nil {
+		return err
+	}
+	fn()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		cur, err := statStamp(name)
+		if err != nil {
+			// The file may be being replaced by an editor.
+			continue
+		}
+		if cur != last {
+			last, pending = cur, true
+			continue
+		}
+		if pending {
+			pending = false
+			fn()
+		}
+	}
+}
+
+// runWatch runs Run with args whenever the font file name changes. Errors of
+// the conversions are logged, and don't stop watching.
+func runWatch(ctx context.Context, name string, args []string) error {
+	slog.Info("watching", "file", name)
+	return watchFile(ctx, name, func() {
+		if err := Run(ctx, args); err != nil {
+			slog.Error("conversion failed", "err", err)
+		}
+	})
+}
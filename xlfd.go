@@ -0,0 +1,127 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// xlfd is the X Logical Font Description, used as the name of the font in
+// the FONT line.
+type xlfd struct {
+	Foundry      string
+	Family       string
+	Weight       string
+	Slant        string
+	Setwidth     string
+	AddStyle     string
+	PixelSize    int
+	PointSize    int
+	ResolutionX  int
+	ResolutionY  int
+	Spacing      string
+	AverageWidth int
+	Registry     string
+	Encoding     string
+}
+
+// xlfdEscaper replaces the characters which can't appear in XLFD fields.
+var xlfdEscaper = strings.NewReplacer("-", " ", "*", " ", "?", " ", ",", " ", `"`, " ")
+
+// Fields returns the 14 fields of the XLFD.
+func (x xlfd) Fields() []string {
+	return []string{
+		x.Foundry,
+		x.Family,
+		x.Weight,
+		x.Slant,
+		x.Setwidth,
+		x.AddStyle,
+		strconv.Itoa(x.PixelSize),
+		strconv.Itoa(x.PointSize),
+		strconv.Itoa(x.ResolutionX),
+		strconv.Itoa(x.ResolutionY),
+		x.Spacing,
+		strconv.Itoa(x.AverageWidth),
+		x.Registry,
+		x.Encoding,
+	}
+}
+
+// String returns the XLFD string. The fields are escaped so the string
+// always has exactly 14 fields.
+func (x xlfd) String() string {
+	b := &strings.Builder{}
+	for _, f := range x.Fields() {
+		b.WriteByte('-')
+		b.WriteString(xlfdEscaper.Replace(f))
+	}
+	return b.String()
+}
+
+// xlfd returns the XLFD of the font with the average width, in tenths of
+// pixels.
+func (cvt *BDFConverter) xlfd(averageWidth int) xlfd {
+	weight, slant := "Medium", "R"
+	if cvt.bold {
+		weight = "Bold"
+	}
+	if cvt.italic > 0 {
+		slant = "O"
+	}
+	registry, encoding := "ISO10646", "1"
+	if cvt.charsetRegistry != "" {
+		registry, encoding = cvt.charsetRegistry, cvt.charsetEncoding
+	}
+	return xlfd{
+		Foundry:      "FreeType",
+		Family:       cvt.fontName(),
+		Weight:       weight,
+		Slant:        slant,
+		Setwidth:     "Normal",
+		PixelSize:    int((float64(cvt.deciPointSize())*float64(cvt.dpi))/722.7 + 0.5),
+		PointSize:    cvt.deciPointSize(),
+		ResolutionX:  cvt.dpi,
+		ResolutionY:  cvt.dpi,
+		Spacing:      cmp.Or(cvt.spacing, "C"),
+		AverageWidth: averageWidth,
+		Registry:     registry,
+		Encoding:     encoding,
+	}
+}
+
+// xlfdSpacing returns the spacing of XLFD for the cell widths of the glyphs
+// other than spaces: "M" (monospaced) when they have the same DWIDTH, "C"
+// (character cell) when they are half and full width, or "P" (proportional)
+// otherwise. DWIDTH adds the same pixels to all the cell widths, and
+// WithRotation gives all the glyphs the same DWIDTH.
+func (cvt *BDFConverter) xlfdSpacing(widths map[int]bool) string {
+	switch {
+	case len(widths) <= 1 || cvt.swapsAxes():
+		return "M"
+	case len(widths) == 2 && widths[cvt.halfWidth] && widths[cvt.fullWidth]:
+		return "C"
+	}
+	return "P"
+}
+
+// validateXLFD checks that s is an XLFD with exactly 14 fields.
+func validateXLFD(s string) error {
+	if !strings.HasPrefix(s, "-") {
+		return fmt.Errorf("invalid XLFD %q: must start with \"-\"", s)
+	}
+	if n := strings.Count(s, "-"); n != 14 {
+		return fmt.Errorf("invalid XLFD %q: has %d fields, want 14", s, n)
+	}
+	return nil
+}
+
+// fontLine returns the name of the font in the FONT line: the XLFD given by
+// WithFontXLFD, or the computed one.
+func (cvt *BDFConverter) fontLine(averageWidth int) string {
+	if cvt.fontXLFD != "" {
+		return cvt.fontXLFD
+	}
+	return cvt.xlfd(averageWidth).String()
+}
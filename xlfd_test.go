@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestFontLineFields(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"default", nil,
+			[]string{"FreeType", "Go", "Medium", "R", "Normal", "", "16", "160", "72", "72", "C", "135", "ISO10646", "1"}},
+		{"bold", []Option{WithBold()},
+			[]string{"FreeType", "Go", "Bold", "R", "Normal", "", "16", "160", "72", "72", "C", "135", "ISO10646", "1"}},
+		{"italic", []Option{WithItalic(0.2)},
+			[]string{"FreeType", "Go", "Medium", "O", "Normal", "", "16", "160", "72", "72", "C", "135", "ISO10646", "1"}},
+		{"charset", []Option{WithCharset("ISO8859", "1")},
+			[]string{"FreeType", "Go", "Medium", "R", "Normal", "", "16", "160", "72", "72", "C", "135", "ISO8859", "1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cvt.Close()
+			line := cvt.fontLine(135)
+			parts := strings.Split(line, "-")
+			if len(parts) != 15 || parts[0] != "" {
+				t.Fatalf("FONT %s: split into %d parts; want 15 with an empty first", line, len(parts))
+			}
+			for i, want := range tc.want {
+				if got := parts[i+1]; got != want {
+					t.Errorf("FONT %s: field %d = %q; want %q", line, i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestXLFDSpacing(t *testing.T) {
+	cvt, err := NewBDFConverterFromBytes(goregular.TTF, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cvt.Close()
+	for _, tc := range []struct {
+		name     string
+		widths   []int
+		rotation int
+		want     string
+	}{
+		{"no glyphs", nil, 0, "M"},
+		{"half only", []int{8}, 0, "M"},
+		{"full only", []int{16}, 0, "M"},
+		{"half and full", []int{8, 16}, 0, "C"},
+		{"other width", []int{8, 12}, 0, "P"},
+		{"three widths", []int{8, 12, 16}, 0, "P"},
+		{"rotated", []int{8, 16}, 90, "M"},
+		{"upside down", []int{8, 16}, 180, "C"},
+	} {
+		widths := map[int]bool{}
+		for _, w := range tc.widths {
+			widths[w] = true
+		}
+		cvt.rotation = tc.rotation
+		if got := cvt.xlfdSpacing(widths); got != tc.want {
+			t.Errorf("%s: xlfdSpacing(%v) = %s; want %s", tc.name, tc.widths, got, tc.want)
+		}
+	}
+}
+
+func TestFontLineSpacing(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, "C"},
+		{"mono", []Option{WithMono()}, "M"},
+		// 'i' to 'l' are all half width, and the space is ignored.
+		{"half width", []Option{WithRuneFilter(inRuneRanges([]runeRange{{' ', ' '}, {'i', 'l'}}))}, "M"},
+		{"rotated", []Option{WithRotation(90)}, "M"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := convertGoRegular(t, 16, tc.opts...)
+			if got := strings.Split(f.Name, "-")[11]; got != tc.want {
+				t.Errorf("FONT %s: spacing %s; want %s", f.Name, got, tc.want)
+			}
+		})
+	}
+}